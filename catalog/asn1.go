@@ -0,0 +1,156 @@
+package catalog
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"time"
+	"unicode/utf16"
+)
+
+// contentInfo is a PKCS#7 ContentInfo.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+// signedData is a PKCS#7 SignedData.
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      contentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+// signerInfo is a PKCS#7 SignerInfo.
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerial
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+	UnauthenticatedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+// certificateTrustList is the content of a catalog.
+type certificateTrustList struct {
+	Version          int `asn1:"optional"`
+	SubjectUsage     []asn1.ObjectIdentifier
+	ListIdentifier   []byte   `asn1:"optional"`
+	SequenceNumber   *big.Int `asn1:"optional"`
+	ThisUpdate       time.Time
+	NextUpdate       time.Time `asn1:"optional"`
+	SubjectAlgorithm pkix.AlgorithmIdentifier
+	TrustedSubjects  []trustedSubject `asn1:"optional"`
+	Extensions       []pkix.Extension `asn1:"optional,explicit,tag:0"`
+}
+
+type trustedSubject struct {
+	Identifier []byte
+	Attributes []attribute `asn1:"optional,set"`
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+// nameValue is a CAT_NAMEVALUE attribute.
+type nameValue struct {
+	Tag   asn1.RawValue // BMPString
+	Flags int64
+	Value []byte // UTF-16LE
+}
+
+// memberInfo is a CAT_MEMBERINFO attribute.
+type memberInfo struct {
+	SubjectGUID asn1.RawValue // BMPString
+	CertVersion int
+}
+
+// indirectData is an SpcIndirectDataContent.
+type indirectData struct {
+	Data          attributeTypeAndValue
+	MessageDigest digestInfo
+}
+
+type attributeTypeAndValue struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"optional"`
+}
+
+type digestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+// values returns the DER encoded elements within a SET OF value.
+func (a attribute) values() ([]asn1.RawValue, error) {
+	var values []asn1.RawValue
+	rest := a.Values.Bytes
+	for len(rest) > 0 {
+		var v asn1.RawValue
+		var err error
+		rest, err = asn1.Unmarshal(rest, &v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// parseAttributes parses a sequence of DER encoded attributes.
+func parseAttributes(der []byte) ([]attribute, error) {
+	var attrs []attribute
+	for len(der) > 0 {
+		var a attribute
+		var err error
+		der, err = asn1.Unmarshal(der, &a)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs, nil
+}
+
+// decodeBMPString decodes big endian UTF-16 text, as used by BMPString.
+func decodeBMPString(b []byte) (string, error) {
+	if len(b)%2 != 0 {
+		return "", errors.New("BMPString has an odd number of bytes")
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[i*2])<<8 | uint16(b[i*2+1])
+	}
+	return trimNull(string(utf16.Decode(units))), nil
+}
+
+// decodeUTF16LE decodes little endian UTF-16 text.
+func decodeUTF16LE(b []byte) (string, error) {
+	if len(b)%2 != 0 {
+		return "", errors.New("UTF-16 text has an odd number of bytes")
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[i*2]) | uint16(b[i*2+1])<<8
+	}
+	return trimNull(string(utf16.Decode(units))), nil
+}
+
+func trimNull(s string) string {
+	for len(s) > 0 && s[len(s)-1] == 0 {
+		s = s[:len(s)-1]
+	}
+	return s
+}
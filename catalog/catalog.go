@@ -0,0 +1,235 @@
+package catalog
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Catalog is a parsed security catalog.
+type Catalog struct {
+	// ListIdentifier is the identifier of the trust list, which is usually
+	// a GUID.
+	ListIdentifier []byte
+
+	// ThisUpdate is the time the catalog was created.
+	ThisUpdate time.Time
+
+	// Attributes holds the catalog-level attributes, such as OS and HWID1.
+	Attributes []Attribute
+
+	// Members describes the files covered by the catalog.
+	Members []Member
+
+	// Certificates holds every certificate embedded in the catalog.
+	Certificates []*x509.Certificate
+
+	// Signers describes the signatures applied to the catalog.
+	Signers []Signer
+
+	// content is the DER encoded certificate trust list, which the
+	// signatures cover.
+	content []byte
+}
+
+// Attribute is a name and value pair attached to a catalog or one of its
+// members.
+type Attribute struct {
+	Name  string
+	Flags uint32
+	Value string
+}
+
+// Load reads and parses the catalog file at path.
+func Load(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses a DER encoded catalog.
+func Parse(data []byte) (*Catalog, error) {
+	var ci contentInfo
+	if _, err := asn1.Unmarshal(data, &ci); err != nil {
+		return nil, fmt.Errorf("catalog is not a PKCS#7 message: %v", err)
+	}
+	if !ci.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("catalog has content type %s instead of SignedData", ci.ContentType)
+	}
+
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("catalog has invalid SignedData: %v", err)
+	}
+	if !sd.ContentInfo.ContentType.Equal(oidCertTrustList) {
+		return nil, fmt.Errorf("catalog has content type %s instead of a certificate trust list", sd.ContentInfo.ContentType)
+	}
+
+	// The trust list is usually embedded directly, but it may also be
+	// wrapped in an OCTET STRING
+	content := sd.ContentInfo.Content.Bytes
+	var wrapped []byte
+	if _, err := asn1.Unmarshal(content, &wrapped); err == nil {
+		content = wrapped
+	}
+
+	var ctl certificateTrustList
+	if _, err := asn1.Unmarshal(content, &ctl); err != nil {
+		return nil, fmt.Errorf("catalog has an invalid certificate trust list: %v", err)
+	}
+	if !hasUsage(ctl.SubjectUsage) {
+		return nil, errors.New("certificate trust list is not a catalog list")
+	}
+
+	cat := &Catalog{
+		ListIdentifier: ctl.ListIdentifier,
+		ThisUpdate:     ctl.ThisUpdate,
+		content:        content,
+	}
+
+	for _, ext := range ctl.Extensions {
+		if !ext.Id.Equal(oidCatalogNameValue) {
+			continue
+		}
+		attr, err := parseNameValue(ext.Value)
+		if err != nil {
+			return nil, fmt.Errorf("catalog has an invalid attribute: %v", err)
+		}
+		cat.Attributes = append(cat.Attributes, attr)
+	}
+
+	for i, subject := range ctl.TrustedSubjects {
+		member, err := parseMember(subject)
+		if err != nil {
+			return nil, fmt.Errorf("catalog member %d: %v", i, err)
+		}
+		cat.Members = append(cat.Members, member)
+	}
+
+	if len(sd.Certificates.Bytes) > 0 {
+		certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("catalog has invalid certificates: %v", err)
+		}
+		cat.Certificates = certs
+	}
+
+	for _, si := range sd.SignerInfos {
+		signer, err := cat.parseSigner(si)
+		if err != nil {
+			return nil, err
+		}
+		cat.Signers = append(cat.Signers, signer)
+	}
+
+	return cat, nil
+}
+
+// Attribute returns the value of the catalog-level attribute with the
+// given name. The name is not case-sensitive.
+func (c *Catalog) Attribute(name string) string {
+	return findAttribute(c.Attributes, name)
+}
+
+// Signed returns true if the catalog carries at least one signature from a
+// certificate embedded in the catalog.
+func (c *Catalog) Signed() bool {
+	for _, signer := range c.Signers {
+		if signer.Certificate != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the member with the given hash. It returns false if no
+// member has the hash.
+func (c *Catalog) Find(hash []byte) (Member, bool) {
+	for _, member := range c.Members {
+		if member.HasHash(hash) {
+			return member, true
+		}
+	}
+	return Member{}, false
+}
+
+// FindFile returns the members with a File attribute matching name. The
+// comparison is not case-sensitive.
+func (c *Catalog) FindFile(name string) []Member {
+	var members []Member
+	for _, member := range c.Members {
+		if strings.EqualFold(member.File(), name) {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func hasUsage(usage []asn1.ObjectIdentifier) bool {
+	for _, oid := range usage {
+		if oid.Equal(oidCatalogList) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNameValue(der []byte) (Attribute, error) {
+	var nv nameValue
+	if _, err := asn1.Unmarshal(der, &nv); err != nil {
+		return Attribute{}, err
+	}
+	name, err := decodeBMPString(nv.Tag.Bytes)
+	if err != nil {
+		return Attribute{}, err
+	}
+	value, err := decodeUTF16LE(nv.Value)
+	if err != nil {
+		return Attribute{}, err
+	}
+	return Attribute{Name: name, Flags: uint32(nv.Flags), Value: value}, nil
+}
+
+func findAttribute(attrs []Attribute, name string) string {
+	for _, attr := range attrs {
+		if strings.EqualFold(attr.Name, name) {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// decodeTag returns a string representation of a member tag. Tags are
+// stored as UTF-16LE text, which is usually the hexadecimal file hash.
+// Tags that aren't text are returned in hexadecimal.
+func decodeTag(b []byte) string {
+	if s, err := decodeUTF16LE(b); err == nil && isPrintable(s) {
+		return s
+	}
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+func isPrintable(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < 0x20 || r == 0xFFFD {
+			return false
+		}
+	}
+	return true
+}
+
+// equalHash returns true if a is a non-empty hash equal to b.
+func equalHash(a, b []byte) bool {
+	return len(a) > 0 && bytes.Equal(a, b)
+}
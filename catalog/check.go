@@ -0,0 +1,147 @@
+package catalog
+
+import (
+	"crypto"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gentlemanautomaton/windevice/driverpackage"
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+// Report describes how well a catalog covers the files of a driver
+// package.
+type Report struct {
+	// CatalogPath is the path of the catalog file.
+	CatalogPath string
+
+	// Catalog is the parsed catalog.
+	Catalog *Catalog
+
+	// Signed is true if the catalog carries a signature, and
+	// SignatureError describes why its signature failed verification.
+	Signed         bool
+	SignatureError error
+
+	// Files describes the coverage of the INF file and each file it
+	// copies.
+	Files []Coverage
+
+	// Unmatched lists catalog members that don't match any package file.
+	Unmatched []Member
+}
+
+// Coverage describes whether a catalog covers a single package file.
+type Coverage struct {
+	// Path is the location of the file relative to the package directory.
+	Path string
+
+	// Image is true if the file was hashed as a portable executable.
+	Image bool
+
+	// Covered is true if a catalog member has the file's hash, and Member
+	// is that member.
+	Covered bool
+	Member  Member
+
+	// Problem explains why the file is not covered.
+	Problem string
+}
+
+// Complete returns true if the catalog is signed, its signature is intact
+// and it covers every file in the package.
+func (r Report) Complete() bool {
+	if !r.Signed || r.SignatureError != nil {
+		return false
+	}
+	for _, f := range r.Files {
+		if !f.Covered {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPackage cross-checks a driver package against its catalog. It reads
+// the INF file at infPath, resolves the catalog and the files that the INF
+// copies on the given architecture, and confirms that the catalog covers
+// each of them.
+func CheckPackage(infPath string, arch inf.Architecture) (Report, error) {
+	manifest, err := driverpackage.Build(infPath, arch)
+	if err != nil {
+		return Report{}, err
+	}
+	if len(manifest.Catalogs) == 0 {
+		return Report{}, fmt.Errorf("the inf file does not name a catalog file for %s", arch)
+	}
+	catalogFile := manifest.Catalogs[0]
+	if catalogFile.Missing {
+		return Report{}, fmt.Errorf("the catalog file \"%s\" does not exist in the package directory", catalogFile.SourcePath)
+	}
+
+	report := Report{CatalogPath: filepath.Join(manifest.Dir, filepath.FromSlash(catalogFile.SourcePath))}
+	report.Catalog, err = Load(report.CatalogPath)
+	if err != nil {
+		return Report{}, err
+	}
+	report.Signed = report.Catalog.Signed()
+	if report.Signed {
+		report.SignatureError = report.Catalog.Verify()
+	}
+
+	paths := []string{filepath.Base(infPath)}
+	for _, f := range manifest.Files {
+		if f.Missing {
+			report.Files = append(report.Files, Coverage{Path: f.SourcePath, Problem: "the file does not exist in the package directory"})
+			continue
+		}
+		paths = append(paths, f.SourcePath)
+	}
+
+	matched := make(map[int]bool)
+	for _, path := range paths {
+		coverage, index := report.Catalog.cover(manifest.Dir, path)
+		if index >= 0 {
+			matched[index] = true
+		}
+		report.Files = append(report.Files, coverage)
+	}
+
+	for i, member := range report.Catalog.Members {
+		if !matched[i] {
+			report.Unmatched = append(report.Unmatched, member)
+		}
+	}
+
+	return report, nil
+}
+
+// cover checks whether the catalog covers a file in dir. It returns the
+// index of the matching member, or -1.
+func (c *Catalog) cover(dir, path string) (Coverage, int) {
+	coverage := Coverage{Path: path}
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	if err != nil {
+		coverage.Problem = err.Error()
+		return coverage, -1
+	}
+
+	for _, algorithm := range []crypto.Hash{crypto.SHA256, crypto.SHA1} {
+		sum, image := FileHash(data, algorithm)
+		coverage.Image = image
+		for i, member := range c.Members {
+			if member.HasHash(sum) {
+				coverage.Covered, coverage.Member = true, member
+				return coverage, i
+			}
+		}
+	}
+
+	if members := c.FindFile(filepath.Base(path)); len(members) > 0 {
+		coverage.Problem = "the catalog lists a file with the same name but a different hash"
+	} else {
+		coverage.Problem = "the catalog does not list the file"
+	}
+	return coverage, -1
+}
@@ -0,0 +1,13 @@
+// Package catalog parses Windows security catalog (.cat) files without
+// relying on the Windows cryptography APIs.
+//
+// A catalog is a PKCS#7 SignedData message that wraps a certificate trust
+// list. Each member of the list describes a file in a driver package by
+// its hash, along with attributes such as the file name and the operating
+// systems it applies to. Catalogs must be DER encoded.
+//
+// Signatures are checked for integrity only. Whether the signer is
+// trusted is a decision for the machine that installs the package.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/catalog-files
+package catalog
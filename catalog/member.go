@@ -0,0 +1,170 @@
+package catalog
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+// Member is a file covered by a catalog.
+type Member struct {
+	// Tag identifies the member. It is usually the hexadecimal SHA-1 or
+	// SHA-256 hash of the file.
+	Tag string
+
+	// SubjectGUID identifies the subject interface package that computed
+	// the hash, which indicates the kind of file that was hashed.
+	SubjectGUID string
+
+	// Attributes holds the member attributes, such as File and OSAttr.
+	Attributes []Attribute
+
+	// Hash is the hash of the file. For portable executables it is the
+	// Authenticode image hash, otherwise it is the hash of the whole file.
+	Hash Hash
+
+	// Image is true when Hash is an Authenticode image hash of a portable
+	// executable file.
+	Image bool
+
+	// PageHashes holds the per-page hashes of a portable executable file,
+	// when the catalog includes them.
+	PageHashes []PageHash
+}
+
+// Hash is a file hash recorded in a catalog.
+type Hash struct {
+	Algorithm crypto.Hash
+	Value     []byte
+}
+
+// String returns the hash in uppercase hexadecimal.
+func (h Hash) String() string {
+	return strings.ToUpper(hex.EncodeToString(h.Value))
+}
+
+// PageHash is the hash of a single page of a portable executable file.
+type PageHash struct {
+	Algorithm crypto.Hash
+	Offset    uint32
+	Value     []byte
+}
+
+// File returns the value of the member's File attribute, which holds the
+// name of the file that was hashed.
+func (m Member) File() string {
+	return m.Attribute("File")
+}
+
+// OSAttr returns the value of the member's OSAttr attribute, which lists
+// the operating systems the file applies to, such as "2:6.1,2:10.0".
+func (m Member) OSAttr() string {
+	return m.Attribute("OSAttr")
+}
+
+// Attribute returns the value of the member attribute with the given name.
+// The name is not case-sensitive.
+func (m Member) Attribute(name string) string {
+	return findAttribute(m.Attributes, name)
+}
+
+// HasHash returns true if hash matches the member's hash or its tag.
+func (m Member) HasHash(hash []byte) bool {
+	if equalHash(m.Hash.Value, hash) {
+		return true
+	}
+	return len(hash) > 0 && strings.EqualFold(m.Tag, hex.EncodeToString(hash))
+}
+
+func parseMember(subject trustedSubject) (Member, error) {
+	m := Member{Tag: decodeTag(subject.Identifier)}
+
+	for _, attr := range subject.Attributes {
+		values, err := attr.values()
+		if err != nil {
+			return Member{}, err
+		}
+		for _, value := range values {
+			switch {
+			case attr.Type.Equal(oidCatalogNameValue):
+				nv, err := parseNameValue(value.FullBytes)
+				if err != nil {
+					return Member{}, err
+				}
+				m.Attributes = append(m.Attributes, nv)
+			case attr.Type.Equal(oidCatalogMemberInfo):
+				var info memberInfo
+				if _, err := asn1.Unmarshal(value.FullBytes, &info); err != nil {
+					return Member{}, err
+				}
+				m.SubjectGUID, _ = decodeBMPString(info.SubjectGUID.Bytes)
+			case attr.Type.Equal(oidIndirectData):
+				var data indirectData
+				if _, err := asn1.Unmarshal(value.FullBytes, &data); err != nil {
+					return Member{}, err
+				}
+				m.Hash = Hash{
+					Algorithm: hashForOID(data.MessageDigest.Algorithm.Algorithm),
+					Value:     data.MessageDigest.Digest,
+				}
+				m.Image = data.Data.Type.Equal(oidPEImageData)
+				if m.Image {
+					m.PageHashes = findPageHashes(data.Data.Value.FullBytes)
+				}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// findPageHashes looks for a page hash table within the DER encoded value
+// of an SpcPeImageData structure. The table is nested within a serialized
+// object, so it is located by searching for its object identifier.
+func findPageHashes(der []byte) []PageHash {
+	for _, candidate := range []struct {
+		oid  asn1.ObjectIdentifier
+		hash crypto.Hash
+	}{
+		{oidPageHashesV2, crypto.SHA256},
+		{oidPageHashesV1, crypto.SHA1},
+	} {
+		marker, err := asn1.Marshal(candidate.oid)
+		if err != nil {
+			continue
+		}
+		i := bytes.Index(der, marker)
+		if i < 0 {
+			continue
+		}
+		var set asn1.RawValue
+		if _, err := asn1.Unmarshal(der[i+len(marker):], &set); err != nil {
+			continue
+		}
+		var table []byte
+		if _, err := asn1.Unmarshal(set.Bytes, &table); err != nil {
+			continue
+		}
+		return parsePageHashTable(table, candidate.hash)
+	}
+	return nil
+}
+
+// parsePageHashTable parses a table of 4-byte file offsets, each followed
+// by the hash of the page at that offset.
+func parsePageHashTable(table []byte, hash crypto.Hash) []PageHash {
+	size := 4 + hash.Size()
+	var pages []PageHash
+	for len(table) >= size {
+		pages = append(pages, PageHash{
+			Algorithm: hash,
+			Offset:    binary.LittleEndian.Uint32(table),
+			Value:     table[4:size],
+		})
+		table = table[size:]
+	}
+	return pages
+}
@@ -0,0 +1,80 @@
+package catalog
+
+import (
+	"crypto"
+	_ "crypto/sha1"   // Register SHA-1 for catalog hashes
+	_ "crypto/sha256" // Register SHA-256 for catalog hashes
+	_ "crypto/sha512" // Register SHA-384 and SHA-512 for signatures
+	"encoding/binary"
+	"hash"
+)
+
+// FileHash computes the hash that a catalog records for a file. For
+// portable executable files it is the Authenticode image hash, which
+// excludes the checksum and the embedded signature. For other files it is
+// the hash of the whole file. It returns true if the file was hashed as a
+// portable executable.
+func FileHash(data []byte, algorithm crypto.Hash) (sum []byte, image bool) {
+	h := algorithm.New()
+	if imageHash(data, h) {
+		return h.Sum(nil), true
+	}
+	h.Reset()
+	h.Write(data)
+	return h.Sum(nil), false
+}
+
+// imageHash writes the Authenticode image hash input of a portable
+// executable to h. It returns false if data is not a portable executable.
+//
+// https://download.microsoft.com/download/9/c/5/9c5b2167-8017-4bae-9fde-d599bac8184a/Authenticode_PE.docx
+func imageHash(data []byte, h hash.Hash) bool {
+	if len(data) < 0x40 || data[0] != 'M' || data[1] != 'Z' {
+		return false
+	}
+	peOffset := int(binary.LittleEndian.Uint32(data[0x3C:]))
+	if peOffset < 0 || peOffset+24 > len(data) || string(data[peOffset:peOffset+4]) != "PE\x00\x00" {
+		return false
+	}
+
+	optional := peOffset + 24
+	if optional+2 > len(data) {
+		return false
+	}
+	var directories int
+	switch binary.LittleEndian.Uint16(data[optional:]) {
+	case 0x10B: // PE32
+		directories = optional + 96
+	case 0x20B: // PE32+
+		directories = optional + 112
+	default:
+		return false
+	}
+	if directories > len(data) {
+		return false
+	}
+
+	checksum := optional + 64
+	count := int(binary.LittleEndian.Uint32(data[directories-4:]))
+	security := directories + 4*8 // IMAGE_DIRECTORY_ENTRY_SECURITY
+
+	if count <= 4 || security+8 > len(data) {
+		// There is no certificate table entry to exclude
+		h.Write(data[:checksum])
+		h.Write(data[checksum+4:])
+		return true
+	}
+
+	certOffset := int(binary.LittleEndian.Uint32(data[security:]))
+	certSize := int(binary.LittleEndian.Uint32(data[security+4:]))
+
+	h.Write(data[:checksum])
+	h.Write(data[checksum+4 : security])
+	if certOffset > 0 && certSize > 0 && certOffset >= security+8 && certOffset+certSize <= len(data) {
+		h.Write(data[security+8 : certOffset])
+		h.Write(data[certOffset+certSize:])
+	} else {
+		h.Write(data[security+8:])
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package catalog
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Signer describes a signature applied to a catalog.
+type Signer struct {
+	// Certificate is the certificate that made the signature. It is nil
+	// if the certificate is not embedded in the catalog.
+	Certificate *x509.Certificate
+
+	// Chain holds the signer's certificate followed by each issuer that
+	// could be found among the embedded certificates.
+	Chain []*x509.Certificate
+
+	// DigestAlgorithm is the hash function used to compute the signature.
+	DigestAlgorithm crypto.Hash
+
+	// SigningTime is the signing time claimed by the signer, if present.
+	// It is not a trusted timestamp.
+	SigningTime time.Time
+
+	info signerInfo
+}
+
+func (c *Catalog) parseSigner(si signerInfo) (Signer, error) {
+	signer := Signer{
+		DigestAlgorithm: hashForOID(si.DigestAlgorithm.Algorithm),
+		info:            si,
+	}
+
+	for _, cert := range c.Certificates {
+		if bytes.Equal(cert.RawIssuer, si.IssuerAndSerialNumber.Issuer.FullBytes) && cert.SerialNumber.Cmp(si.IssuerAndSerialNumber.SerialNumber) == 0 {
+			signer.Certificate = cert
+			break
+		}
+	}
+	if signer.Certificate != nil {
+		signer.Chain = c.chain(signer.Certificate)
+	}
+
+	if len(si.AuthenticatedAttributes.Bytes) > 0 {
+		attrs, err := parseAttributes(si.AuthenticatedAttributes.Bytes)
+		if err != nil {
+			return Signer{}, fmt.Errorf("catalog signer has invalid authenticated attributes: %v", err)
+		}
+		for _, attr := range attrs {
+			if !attr.Type.Equal(oidSigningTime) {
+				continue
+			}
+			values, err := attr.values()
+			if err != nil || len(values) == 0 {
+				continue
+			}
+			var t time.Time
+			if _, err := asn1.Unmarshal(values[0].FullBytes, &t); err == nil {
+				signer.SigningTime = t
+			}
+		}
+	}
+
+	return signer, nil
+}
+
+// chain builds a certificate chain from the embedded certificates,
+// starting with cert. Issuers are matched by name.
+func (c *Catalog) chain(cert *x509.Certificate) []*x509.Certificate {
+	chain := []*x509.Certificate{cert}
+	for len(chain) <= len(c.Certificates) {
+		current := chain[len(chain)-1]
+		if bytes.Equal(current.RawIssuer, current.RawSubject) {
+			break // Self-signed
+		}
+		var issuer *x509.Certificate
+		for _, candidate := range c.Certificates {
+			if candidate != current && bytes.Equal(candidate.RawSubject, current.RawIssuer) {
+				issuer = candidate
+				break
+			}
+		}
+		if issuer == nil {
+			break
+		}
+		chain = append(chain, issuer)
+	}
+	return chain
+}
+
+// Verify checks the integrity of every signature in the catalog. It
+// confirms that the catalog content matches the signed digest and that
+// each signature was made by the embedded signer certificate. It does not
+// check whether the certificates are trusted, valid or revoked.
+func (c *Catalog) Verify() error {
+	if len(c.Signers) == 0 {
+		return errors.New("the catalog is not signed")
+	}
+	for i, signer := range c.Signers {
+		if err := signer.verify(c.content); err != nil {
+			return fmt.Errorf("catalog signature %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func (s Signer) verify(content []byte) error {
+	if s.Certificate == nil {
+		return errors.New("the signer certificate is not embedded in the catalog")
+	}
+	hash := s.DigestAlgorithm
+	if hash == 0 || !hash.Available() {
+		return fmt.Errorf("unsupported digest algorithm %s", s.info.DigestAlgorithm.Algorithm)
+	}
+
+	signed := content
+	if len(s.info.AuthenticatedAttributes.Bytes) > 0 {
+		attrs, err := parseAttributes(s.info.AuthenticatedAttributes.Bytes)
+		if err != nil {
+			return err
+		}
+		var digest []byte
+		for _, attr := range attrs {
+			if !attr.Type.Equal(oidMessageDigest) {
+				continue
+			}
+			values, err := attr.values()
+			if err != nil || len(values) == 0 {
+				return errors.New("the message digest attribute is invalid")
+			}
+			if _, err := asn1.Unmarshal(values[0].FullBytes, &digest); err != nil {
+				return errors.New("the message digest attribute is invalid")
+			}
+		}
+		if digest == nil {
+			return errors.New("the signature does not include a message digest")
+		}
+		if !contentMatches(hash, content, digest) {
+			return errors.New("the catalog content does not match the signed message digest")
+		}
+
+		// The signature covers the attributes encoded as a SET rather than
+		// with their implicit context-specific tag
+		signed = append([]byte{0x31}, s.info.AuthenticatedAttributes.FullBytes[1:]...)
+	}
+
+	h := hash.New()
+	h.Write(signed)
+	sum := h.Sum(nil)
+
+	switch pub := s.Certificate.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pub, hash, sum, s.info.EncryptedDigest); err != nil {
+			return fmt.Errorf("the signature is invalid: %v", err)
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pub, sum, s.info.EncryptedDigest) {
+			return errors.New("the signature is invalid")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return nil
+}
+
+// contentMatches returns true if the digest of the content matches digest.
+// Signers hash the content of the trust list without its outer tag and
+// length, as Authenticode does, although some hash the whole structure.
+func contentMatches(hash crypto.Hash, content, digest []byte) bool {
+	var raw asn1.RawValue
+	if _, err := asn1.Unmarshal(content, &raw); err == nil {
+		h := hash.New()
+		h.Write(raw.Bytes)
+		if bytes.Equal(h.Sum(nil), digest) {
+			return true
+		}
+	}
+	h := hash.New()
+	h.Write(content)
+	return bytes.Equal(h.Sum(nil), digest)
+}
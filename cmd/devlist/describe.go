@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gentlemanautomaton/windevice/deviceid"
+	"github.com/gentlemanautomaton/windevice/hwids"
+	"github.com/gentlemanautomaton/windevice/pnpid"
+)
+
+// Databases used to look up PCI and USB names. They default to the
+// snapshots embedded in the hwids package.
+var (
+	pciDB = hwids.PCI()
+	usbDB = hwids.USB()
+)
+
+// describeID returns a human readable description of a hardware or
+// compatible identifier. It returns an empty string if nothing is known
+// about the identifier.
+func describeID(id string) string {
+	if acpi, err := deviceid.ParseACPI(id); err == nil {
+		if name := pnpid.Device(acpi.ID()); name != "" {
+			return name
+		}
+		return pnpid.Vendor(acpi.Vendor)
+	}
+	if monitor, err := deviceid.ParseMonitor(id); err == nil {
+		return pnpid.Vendor(monitor.Vendor)
+	}
+	if pci, err := deviceid.ParsePCI(id); err == nil {
+		return pciDB.DescribePCI(pci).String()
+	}
+	if usb, err := deviceid.ParseUSB(id); err == nil {
+		return usbDB.DescribeUSB(usb).String()
+	}
+	if hid, err := deviceid.ParseHID(id); err == nil {
+		return usbDB.DescribeHID(hid).String()
+	}
+	return ""
+}
+
+// printID prints a labeled hardware or compatible identifier along with its
+// description, if one is available.
+func printID(label, id string) {
+	if desc := describeID(id); desc != "" {
+		fmt.Printf("      %s: %s (%s)\n", label, id, desc)
+	} else {
+		fmt.Printf("      %s: %s\n", label, id)
+	}
+}
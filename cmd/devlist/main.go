@@ -8,6 +8,7 @@ import (
 	"github.com/gentlemanautomaton/windevice"
 	"github.com/gentlemanautomaton/windevice/deviceclass"
 	"github.com/gentlemanautomaton/windevice/devselect"
+	"github.com/gentlemanautomaton/windevice/hwids"
 	"github.com/gentlemanautomaton/windevice/hwprofile"
 	"github.com/gentlemanautomaton/windevice/strmatch"
 )
@@ -23,6 +24,8 @@ func main() {
 		detail     bool
 		props      bool
 		remove     bool
+		pciIDs     string
+		usbIDs     string
 	)
 
 	flag.StringVar(&className, "class", "", "include devices from a named device class")
@@ -34,9 +37,28 @@ func main() {
 	flag.BoolVar(&detail, "detail", false, "print extra detail about each device")
 	flag.BoolVar(&props, "props", false, "show all device properties")
 	flag.BoolVar(&remove, "remove", false, "remove a single matched device")
+	flag.StringVar(&pciIDs, "pciids", "", "load PCI names from a pci.ids file instead of the embedded snapshot")
+	flag.StringVar(&usbIDs, "usbids", "", "load USB names from a usb.ids file instead of the embedded snapshot")
 
 	flag.Parse()
 
+	if pciIDs != "" {
+		db, err := hwids.Load(pciIDs)
+		if err != nil {
+			fmt.Printf("Unable to load PCI IDs from \"%s\": %v\n", pciIDs, err)
+			os.Exit(1)
+		}
+		pciDB = db
+	}
+	if usbIDs != "" {
+		db, err := hwids.Load(usbIDs)
+		if err != nil {
+			fmt.Printf("Unable to load USB IDs from \"%s\": %v\n", usbIDs, err)
+			os.Exit(1)
+		}
+		usbDB = db
+	}
+
 	q := windevice.DeviceQuery{
 		Enumerator: enumerator,
 		Machine:    machine,
@@ -152,6 +174,10 @@ func printDetail(device windevice.Device, index int, allProps bool) {
 	if location, _ := device.LocationInformation(); location != "" {
 		fmt.Printf("      Location: %s\n", location)
 	}
+	paths, _ := device.LocationPaths()
+	for _, path := range paths {
+		fmt.Printf("      Location Path: %s\n", path)
+	}
 	if mfg, _ := device.Manufacturer(); mfg != "" {
 		fmt.Printf("      Manufacturer: %s\n", mfg)
 	}
@@ -191,12 +217,12 @@ func printDetail(device windevice.Device, index int, allProps bool) {
 	}
 	if ids, _ := device.HardwareID(); len(ids) > 0 {
 		for _, id := range ids {
-			fmt.Printf("      Hardware ID: %s\n", id)
+			printID("Hardware ID", string(id))
 		}
 	}
 	if ids, _ := device.CompatibleID(); len(ids) > 0 {
 		for _, id := range ids {
-			fmt.Printf("      Compatible ID: %s\n", id)
+			printID("Compatible ID", string(id))
 		}
 	}
 	if flags, _ := device.ConfigFlags(); flags != 0 {
@@ -0,0 +1,77 @@
+// Command driverindex maintains an index of the driver packages in a
+// directory tree and answers questions about which packages support a
+// hardware ID.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/gentlemanautomaton/windevice/driverindex"
+)
+
+func main() {
+	var (
+		indexPath string
+		scanRoot  string
+		id        string
+	)
+
+	flag.StringVar(&indexPath, "index", "driverindex.json", "path of the JSON index file")
+	flag.StringVar(&scanRoot, "scan", "", "scan a directory tree of driver packages and update the index")
+	flag.StringVar(&id, "id", "", "list the packages that support a hardware or compatible identifier")
+
+	flag.Parse()
+
+	if scanRoot == "" && id == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	idx, err := driverindex.Load(indexPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist) && scanRoot != "":
+		idx = &driverindex.Index{}
+	case err != nil:
+		fmt.Printf("Unable to load index: %v\n", err)
+		os.Exit(1)
+	}
+
+	if scanRoot != "" {
+		result, err := idx.Scan(scanRoot)
+		if err != nil {
+			fmt.Printf("Unable to scan \"%s\": %v\n", scanRoot, err)
+			os.Exit(1)
+		}
+		if err := idx.Save(indexPath); err != nil {
+			fmt.Printf("Unable to save index: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Scanned %s: %d added, %d updated, %d unchanged, %d removed, %d failed to parse, %d with warnings, %d skipped\n",
+			scanRoot, result.Added, result.Updated, result.Unchanged, result.Removed, result.Failed, result.Warned, result.Skipped)
+	}
+
+	if id != "" {
+		matches := idx.Lookup(id)
+		if len(matches) == 0 {
+			fmt.Printf("No packages support %s.\n", id)
+			return
+		}
+		for i, match := range matches {
+			kind := "Hardware ID"
+			if match.Compatible {
+				kind = "Compatible ID"
+			}
+			fmt.Printf(" %3d: %s\n", i, match.Package.Path)
+			fmt.Printf("      Description: %s\n", match.Model.Description)
+			fmt.Printf("      Provider: %s\n", match.Package.Provider)
+			fmt.Printf("      Class: %s\n", match.Package.Class)
+			fmt.Printf("      Version: %s, Released: %s\n", match.Package.Version, match.Package.Date.Format("2006-01-02"))
+			fmt.Printf("      Section: %s (%s)\n", match.Model.Section, match.Model.Architecture)
+			fmt.Printf("      Matched: %s\n", kind)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Command inflint reports structural problems in setup information (INF)
+// files.
+//
+// Each argument may be an INF file or a directory, which is searched
+// recursively for INF files. The command exits with a status of 1 when any
+// error is found.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/inflint"
+)
+
+func main() {
+	var (
+		jsonOutput bool
+		listRules  bool
+		strict     bool
+	)
+
+	flag.BoolVar(&jsonOutput, "json", false, "print findings as JSON")
+	flag.BoolVar(&listRules, "rules", false, "list the rules that are checked and exit")
+	flag.BoolVar(&strict, "strict", false, "exit with an error status when warnings are found")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] path...\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if listRules {
+		for _, rule := range inflint.Rules {
+			fmt.Printf("%s %-7s %s\n", rule.Code, rule.Severity, rule.Summary)
+		}
+		return
+	}
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	files, err := collect(flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
+	findings := []inflint.Finding{}
+	for _, file := range files {
+		findings = append(findings, inflint.CheckFile(file)...)
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(findings); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2)
+		}
+	} else {
+		for _, finding := range findings {
+			fmt.Println(finding)
+		}
+	}
+
+	if inflint.HasErrors(findings) || (strict && len(findings) > 0) {
+		os.Exit(1)
+	}
+}
+
+// collect returns the INF files named by paths. Directories are searched
+// recursively.
+func collect(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".inf") {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
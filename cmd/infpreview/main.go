@@ -0,0 +1,145 @@
+// Command infpreview describes the changes that a driver package makes to
+// a system when it is installed, including the files it copies, the
+// registry entries it adds, deletes or modifies and the services it
+// installs.
+//
+// Each install section used by the models that apply to the target
+// platform is previewed once. The -hwid flag limits the preview to models
+// that match a hardware or compatible ID.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+func main() {
+	var (
+		hwid        string
+		arch        string
+		osVersion   string
+		productType uint
+	)
+
+	flag.StringVar(&hwid, "hwid", "", "only preview models that match this hardware or compatible ID")
+	flag.StringVar(&arch, "arch", string(inf.DefaultArchitecture()), "processor architecture of the target platform")
+	flag.StringVar(&osVersion, "os", "10.0.19041", "Windows version of the target platform in major.minor.build form")
+	flag.UintVar(&productType, "producttype", inf.ProductWorkstation, "product type of the target platform (1 workstation, 2 domain controller, 3 server)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: infpreview [flags] file.inf\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	platform, err := inf.ParsePlatform(arch, osVersion, uint32(productType))
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(2)
+	}
+
+	doc, err := inf.Load(flag.Arg(0))
+	if err != nil {
+		fmt.Printf("Unable to read INF file: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Group the models by install section, in the order they appear
+	var sections []string
+	models := make(map[string][]inf.Model)
+	for _, model := range doc.Models(platform) {
+		if hwid != "" && !model.Matches(hwid) {
+			continue
+		}
+		key := strings.ToLower(model.InstallSection)
+		if _, seen := models[key]; !seen {
+			sections = append(sections, model.InstallSection)
+		}
+		models[key] = append(models[key], model)
+	}
+
+	if len(sections) == 0 {
+		if hwid != "" {
+			fmt.Printf("No models for %s apply to %s.\n", hwid, platform)
+		} else {
+			fmt.Printf("No models apply to %s.\n", platform)
+		}
+		os.Exit(1)
+	}
+
+	failed := false
+	for i, section := range sections {
+		preview, err := doc.Preview(section, platform.Architecture)
+		if err != nil {
+			fmt.Printf(" %3d: %s: %v\n", i, section, err)
+			failed = true
+			continue
+		}
+		fmt.Printf(" %3d: %s\n", i, preview.Section)
+		for _, model := range models[strings.ToLower(section)] {
+			fmt.Printf("      Model: %s (%s)\n", model.Description, model.HardwareID)
+		}
+		printPreview(preview)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func printPreview(preview inf.Preview) {
+	for _, file := range preview.Files {
+		if file.Source != file.Destination {
+			fmt.Printf("      File: %s (from %s)\n", file.Destination, file.Source)
+		} else {
+			fmt.Printf("      File: %s\n", file.Destination)
+		}
+	}
+	for _, entry := range preview.Software {
+		fmt.Printf("      Software Key: %s\n", entry)
+	}
+	for _, entry := range preview.Hardware {
+		fmt.Printf("      Hardware Key: %s\n", entry)
+	}
+	for _, service := range preview.Services {
+		fmt.Printf("      Service: %s\n", service)
+		if service.DisplayName != "" {
+			fmt.Printf("        Display Name: %s\n", service.DisplayName)
+		}
+		if service.InstallSection != "" {
+			fmt.Printf("        Error Control: %s\n", service.ErrorControl)
+		}
+		if service.StartName != "" {
+			fmt.Printf("        Account: %s\n", service.StartName)
+		}
+		if len(service.Dependencies) > 0 {
+			fmt.Printf("        Dependencies: %s\n", strings.Join(service.Dependencies, ", "))
+		}
+		if service.Security != "" {
+			fmt.Printf("        Security: %s\n", service.Security)
+		}
+		for _, entry := range service.Registry {
+			fmt.Printf("        Service Key: %s\n", entry)
+		}
+		if service.EventLogSection != "" {
+			fmt.Printf("        Event Log: %s\\%s\n", service.EventLogType, service.EventName)
+			for _, entry := range service.EventLogRegistry {
+				fmt.Printf("        Event Log Key: %s\n", entry)
+			}
+		}
+	}
+	for _, include := range preview.Includes {
+		fmt.Printf("      Include: %s\n", include)
+	}
+	for _, needs := range preview.Needs {
+		fmt.Printf("      Needs: %s (not previewed)\n", needs)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// loadInventory reads devices from a JSON file. The file may hold a
+// single device or an array of devices, each in the form:
+//
+//	{
+//	  "instanceId": "PCI\\VEN_8086&DEV_15F3&SUBSYS_00008086&REV_03\\3&11583659&0&E8",
+//	  "description": "Ethernet Controller",
+//	  "hardwareIds": ["PCI\\VEN_8086&DEV_15F3&SUBSYS_00008086&REV_03", "..."],
+//	  "compatibleIds": ["PCI\\VEN_8086&DEV_15F3&REV_03", "..."]
+//	}
+func loadInventory(path string) ([]inventoryDevice, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var devices []inventoryDevice
+		if err := json.Unmarshal(data, &devices); err != nil {
+			return nil, err
+		}
+		return devices, nil
+	}
+	var device inventoryDevice
+	if err := json.Unmarshal(data, &device); err != nil {
+		return nil, err
+	}
+	return []inventoryDevice{device}, nil
+}
+
+// filterInstance returns the devices with the given device instance ID.
+func filterInstance(devices []inventoryDevice, instance string) []inventoryDevice {
+	var filtered []inventoryDevice
+	for _, device := range devices {
+		if strings.EqualFold(device.InstanceID, instance) {
+			filtered = append(filtered, device)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,166 @@
+// Command whichdriver predicts which driver Windows would select for a
+// device, given the device's hardware and compatible IDs and a set of INF
+// files.
+//
+// Device IDs can be supplied with the -hwid and -compatid flags, or read
+// from a captured device inventory with the -inventory flag. The remaining
+// arguments name INF files or directories that contain them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/deviceid"
+	"github.com/gentlemanautomaton/windevice/drivermatch"
+	"github.com/gentlemanautomaton/windevice/driverrank"
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+func main() {
+	var (
+		hardwareIDs   idList
+		compatibleIDs idList
+		inventory     string
+		instance      string
+		arch          string
+		osVersion     string
+		productType   uint
+		all           bool
+	)
+
+	flag.Var(&hardwareIDs, "hwid", "a hardware identifier of the device, may be repeated")
+	flag.Var(&compatibleIDs, "compatid", "a compatible identifier of the device, may be repeated")
+	flag.StringVar(&inventory, "inventory", "", "read devices from a JSON inventory file")
+	flag.StringVar(&instance, "instance", "", "only evaluate the inventory device with this device instance ID")
+	flag.StringVar(&arch, "arch", string(inf.DefaultArchitecture()), "processor architecture of the target platform")
+	flag.StringVar(&osVersion, "os", "10.0.19041", "Windows version of the target platform in major.minor.build form")
+	flag.UintVar(&productType, "producttype", inf.ProductWorkstation, "product type of the target platform (1 workstation, 2 domain controller, 3 server)")
+	flag.BoolVar(&all, "all", false, "list every compatible driver instead of the selected driver only")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: whichdriver [flags] path...\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	platform, err := inf.ParsePlatform(arch, osVersion, uint32(productType))
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(2)
+	}
+
+	var devices []inventoryDevice
+	if inventory != "" {
+		devices, err = loadInventory(inventory)
+		if err != nil {
+			fmt.Printf("Unable to read inventory: %v\n", err)
+			os.Exit(1)
+		}
+		if instance != "" {
+			devices = filterInstance(devices, instance)
+		}
+	}
+	if len(hardwareIDs) > 0 || len(compatibleIDs) > 0 {
+		devices = append(devices, inventoryDevice{
+			HardwareIDs:   hardwareIDs,
+			CompatibleIDs: compatibleIDs,
+		})
+	}
+
+	if len(devices) == 0 || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	sources, failed, err := drivermatch.Load(flag.Args()...)
+	if err != nil {
+		fmt.Printf("Unable to read INF files: %v\n", err)
+		os.Exit(1)
+	}
+	for _, f := range failed {
+		fmt.Printf("Unable to read INF file %v\n", f)
+	}
+
+	opts := drivermatch.Options{Platform: platform}
+	for i, device := range devices {
+		printDevice(device, i)
+		drivers := drivermatch.Drivers(device.Device(), sources, opts)
+		switch {
+		case len(drivers) == 0:
+			fmt.Printf("      Drivers: None\n")
+		case all:
+			fmt.Printf("      Drivers:\n")
+			for d, driver := range drivers {
+				printDriver(driver, d)
+			}
+		default:
+			fmt.Printf("      Selected Driver:\n")
+			printDriver(drivers[0], 0)
+		}
+	}
+}
+
+func printDevice(device inventoryDevice, index int) {
+	name := device.Description
+	if name == "" {
+		name = device.InstanceID
+	}
+	if name == "" {
+		name = "Device"
+	}
+	fmt.Printf(" %3d: %s\n", index, name)
+	if device.InstanceID != "" && device.InstanceID != name {
+		fmt.Printf("      Device Instance ID: %s\n", device.InstanceID)
+	}
+	for _, id := range device.HardwareIDs {
+		fmt.Printf("      Hardware ID: %s\n", id)
+	}
+	for _, id := range device.CompatibleIDs {
+		fmt.Printf("      Compatible ID: %s\n", id)
+	}
+}
+
+func printDriver(driver drivermatch.Driver, index int) {
+	fmt.Printf("        %2d: Description: %s\n", index, driver.Description())
+	fmt.Printf("            INF: %s [%s] -> [%s]\n", driver.InfPath, driver.ModelsSection, driver.InstallSection)
+	fmt.Printf("            Manufacturer: %s\n", driver.ManufacturerName())
+	fmt.Printf("            Provider: %s\n", driver.ProviderName())
+	fmt.Printf("            Date: %s\n", driver.Date().Format("2006-01-02"))
+	fmt.Printf("            Version: %s\n", driver.Version())
+	fmt.Printf("            Match: %s\n", driver.Match)
+}
+
+// idList is a flag value that collects repeated identifiers.
+type idList []string
+
+func (list *idList) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *idList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+// inventoryDevice is a device in a captured inventory.
+type inventoryDevice struct {
+	InstanceID    string   `json:"instanceId,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	HardwareIDs   []string `json:"hardwareIds"`
+	CompatibleIDs []string `json:"compatibleIds"`
+}
+
+// Device returns the identifiers of the device for ranking.
+func (device inventoryDevice) Device() driverrank.Device {
+	var d driverrank.Device
+	for _, id := range device.HardwareIDs {
+		d.HardwareIDs = append(d.HardwareIDs, deviceid.Hardware(id))
+	}
+	for _, id := range device.CompatibleIDs {
+		d.CompatibleIDs = append(d.CompatibleIDs, deviceid.Compatible(id))
+	}
+	return d
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/gentlemanautomaton/windevice/drivertype"
 	"github.com/gentlemanautomaton/windevice/hwprofile"
 	"github.com/gentlemanautomaton/windevice/installstate"
+	"github.com/gentlemanautomaton/windevice/locationpath"
 	"github.com/gentlemanautomaton/windevice/setupapi"
 )
 
@@ -124,6 +125,18 @@ func (device Device) Properties() ([]deviceproperty.Property, error) {
 	return props, nil
 }
 
+// SetProperty sets the device property identified by key to value. To
+// delete the property, pass a value with the deviceproperty.Empty type
+// (DEVPROP_TYPE_EMPTY) and no data, such as the zero Value.
+//
+// Values can be created with the constructors in the deviceproperty
+// package, such as deviceproperty.NewString or deviceproperty.NewUint32.
+// Most system-defined properties are read-only; custom properties should
+// use a property category GUID that belongs to the caller.
+func (device Device) SetProperty(key deviceproperty.Key, value deviceproperty.Value) error {
+	return setupapi.SetDeviceProperty(device.devices, device.data, key, value)
+}
+
 // Description returns the description of the device.
 func (device Device) Description() (string, error) {
 	return setupapi.GetDeviceRegistryString(device.devices, device.data, deviceregistry.Description)
@@ -196,6 +209,19 @@ func (device Device) LocationInformation() (string, error) {
 	return setupapi.GetDeviceRegistryString(device.devices, device.data, deviceregistry.LocationInformation)
 }
 
+// LocationPaths returns the set of location paths for the device.
+func (device Device) LocationPaths() ([]locationpath.Path, error) {
+	values, err := setupapi.GetDeviceRegistryStrings(device.devices, device.data, deviceregistry.LocationPaths)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]locationpath.Path, 0, len(values))
+	for _, value := range values {
+		paths = append(paths, locationpath.Path(value))
+	}
+	return paths, nil
+}
+
 // PhysicalDeviceObjectName returns the physical object name of the device.
 func (device Device) PhysicalDeviceObjectName() (string, error) {
 	return setupapi.GetDeviceRegistryString(device.devices, device.data, deviceregistry.PhysicalDeviceObjectName)
@@ -0,0 +1,165 @@
+package deviceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ACPIFormat identifies the textual form of an ACPI or legacy PNP identifier.
+type ACPIFormat int
+
+// ACPI and PNP identifier formats.
+const (
+	ACPICompact      ACPIFormat = iota // ACPI\PNP0A08
+	ACPIVendorDevice                   // ACPI\VEN_PNP&DEV_0A08
+	ACPILegacy                         // *PNP0A08
+)
+
+// ACPI holds the fields of an ACPI-enumerated hardware or compatible
+// identifier, such as ACPI\PNP0A08, ACPI\VEN_INT&DEV_33A1 or *PNP0501.
+//
+// The vendor is either a three-letter PNP vendor ID or a four-character
+// ACPI vendor ID. It is followed by a four-digit hexadecimal product number.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/identifiers-for-acpi-devices
+type ACPI struct {
+	Vendor  string // PNP, INT, MSFT, ACPI
+	Product uint16 // 0A08
+	Format  ACPIFormat
+}
+
+// ParseACPI parses an ACPI or legacy PNP identifier. Device identifiers and
+// device instance identifiers are also accepted, in which case the instance
+// component is ignored.
+func ParseACPI(id string) (ACPI, error) {
+	if legacy, ok := cutPrefixFold(id, "*"); ok {
+		vendor, product, err := splitPNPID(legacy)
+		if err != nil {
+			return ACPI{}, fmt.Errorf("invalid PNP identifier \"%s\": %v", id, err)
+		}
+		return ACPI{Vendor: vendor, Product: product, Format: ACPILegacy}, nil
+	}
+
+	enumerator, specific := splitID(id)
+	if !strings.EqualFold(enumerator, "ACPI") && !strings.EqualFold(enumerator, "ACPI_HAL") {
+		return ACPI{}, fmt.Errorf("not an ACPI identifier: %s", id)
+	}
+
+	if rest, ok := cutPrefixFold(specific, "VEN_"); ok {
+		vendor, device, found := strings.Cut(rest, "&")
+		if !found {
+			return ACPI{}, fmt.Errorf("invalid ACPI identifier \"%s\": missing device field", id)
+		}
+		device, ok := cutPrefixFold(device, "DEV_")
+		if !ok {
+			return ACPI{}, fmt.Errorf("invalid ACPI identifier \"%s\": missing device field", id)
+		}
+		if err := validateACPIVendor(vendor); err != nil {
+			return ACPI{}, fmt.Errorf("invalid ACPI identifier \"%s\": %v", id, err)
+		}
+		product, err := parseHex(device, 4)
+		if err != nil {
+			return ACPI{}, fmt.Errorf("invalid ACPI identifier \"%s\": device: %v", id, err)
+		}
+		return ACPI{Vendor: strings.ToUpper(vendor), Product: uint16(product), Format: ACPIVendorDevice}, nil
+	}
+
+	vendor, product, err := splitPNPID(specific)
+	if err != nil {
+		return ACPI{}, fmt.Errorf("invalid ACPI identifier \"%s\": %v", id, err)
+	}
+	return ACPI{Vendor: vendor, Product: product, Format: ACPICompact}, nil
+}
+
+// splitPNPID splits a PNP or ACPI ID such as PNP0A08 or MSFT0101 into its
+// vendor and product components.
+func splitPNPID(id string) (vendor string, product uint16, err error) {
+	if len(id) != 7 && len(id) != 8 {
+		return "", 0, fmt.Errorf("expected a 7 or 8 character ID but found \"%s\"", id)
+	}
+	split := len(id) - 4
+	if err := validateACPIVendor(id[:split]); err != nil {
+		return "", 0, err
+	}
+	v, err := parseHex(id[split:], 4)
+	if err != nil {
+		return "", 0, fmt.Errorf("product: %v", err)
+	}
+	return strings.ToUpper(id[:split]), uint16(v), nil
+}
+
+// validateACPIVendor returns an error if vendor is not a three-letter PNP
+// vendor ID or a four-character ACPI vendor ID.
+func validateACPIVendor(vendor string) error {
+	switch len(vendor) {
+	case 3:
+		for i := 0; i < len(vendor); i++ {
+			if !isLetter(vendor[i]) {
+				return fmt.Errorf("PNP vendor ID must contain three letters: \"%s\"", vendor)
+			}
+		}
+	case 4:
+		for i := 0; i < len(vendor); i++ {
+			if !isLetter(vendor[i]) && !isDigit(vendor[i]) {
+				return fmt.Errorf("ACPI vendor ID must contain four letters or digits: \"%s\"", vendor)
+			}
+		}
+	default:
+		return fmt.Errorf("vendor ID must contain three or four characters: \"%s\"", vendor)
+	}
+	return nil
+}
+
+// ID returns the combined vendor and product ID, such as PNP0A08.
+func (a ACPI) ID() string {
+	return fmt.Sprintf("%s%04X", a.Vendor, a.Product)
+}
+
+// String returns the identifier formed by the fields of a in its format.
+func (a ACPI) String() string {
+	switch a.Format {
+	case ACPIVendorDevice:
+		return fmt.Sprintf(`ACPI\VEN_%s&DEV_%04X`, a.Vendor, a.Product)
+	case ACPILegacy:
+		return "*" + a.ID()
+	default:
+		return `ACPI\` + a.ID()
+	}
+}
+
+// HardwareIDs returns the ranked list of hardware identifiers that Windows
+// generates for an ACPI device with a hardware ID (_HID) described by a.
+func (a ACPI) HardwareIDs() []Hardware {
+	var ids []Hardware
+	for _, id := range a.forms() {
+		ids = append(ids, Hardware(id))
+	}
+	return ids
+}
+
+// CompatibleIDs returns the ranked list of compatible identifiers that
+// Windows generates for an ACPI device with a compatible ID (_CID)
+// described by a.
+func (a ACPI) CompatibleIDs() []Compatible {
+	var ids []Compatible
+	for _, id := range a.forms() {
+		ids = append(ids, Compatible(id))
+	}
+	return ids
+}
+
+func (a ACPI) forms() []string {
+	return []string{
+		ACPI{Vendor: a.Vendor, Product: a.Product, Format: ACPIVendorDevice}.String(),
+		ACPI{Vendor: a.Vendor, Product: a.Product, Format: ACPICompact}.String(),
+		ACPI{Vendor: a.Vendor, Product: a.Product, Format: ACPILegacy}.String(),
+	}
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
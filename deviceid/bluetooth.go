@@ -0,0 +1,175 @@
+package deviceid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Bluetooth vendor ID sources.
+const (
+	BluetoothSIGVendor = 0x0001 // Vendor ID assigned by the Bluetooth SIG
+	USBVendor          = 0x0002 // Vendor ID assigned by the USB Implementers Forum
+)
+
+// BluetoothAddress is a 48-bit Bluetooth device address.
+type BluetoothAddress uint64
+
+// ParseBluetoothAddress parses a Bluetooth device address in the form of
+// 12 hexadecimal digits, optionally separated by colons.
+func ParseBluetoothAddress(s string) (BluetoothAddress, error) {
+	digits := strings.ReplaceAll(s, ":", "")
+	v, err := parseHex(digits, 12)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bluetooth address \"%s\": %v", s, err)
+	}
+	return BluetoothAddress(v), nil
+}
+
+// String returns the address in colon-separated hexadecimal notation, such
+// as A4:D1:D2:C3:B4:A5.
+func (addr BluetoothAddress) String() string {
+	return fmt.Sprintf("%02X:%02X:%02X:%02X:%02X:%02X",
+		byte(addr>>40), byte(addr>>32), byte(addr>>24), byte(addr>>16), byte(addr>>8), byte(addr))
+}
+
+// Bluetooth holds the fields of a Bluetooth enumerator identifier, such as
+// BTHENUM\{0000111e-0000-1000-8000-00805f9b34fb}_VID&0001004c_PID&0000 or
+// BTHLE\DEV_E1234567890A\7&1d3f2a&0&E1234567890A.
+//
+// Bluetooth device addresses are usually found in the device or instance
+// component of a device instance identifier, so ParseBluetooth is best used
+// with the value returned by Device.DeviceInstanceID.
+//
+// Each field is only meaningful when its corresponding Has flag is set.
+type Bluetooth struct {
+	Bus               string           // BTHENUM, BTHLE or BTHLEDEVICE
+	Service           string           // {0000111e-0000-1000-8000-00805f9b34fb}
+	VendorIDSource    uint16           // BluetoothSIGVendor or USBVendor
+	VendorID          uint16           // VID&s(4)v(4) or VID&s(2)v(4)
+	ProductID         uint16           // PID&p(4)
+	Revision          uint16           // REV&r(4)
+	LocalManufacturer uint16           // LOCALMFG&m(4)
+	Address           BluetoothAddress // DEV_a(12) or a(12)
+
+	HasService           bool
+	HasVendor            bool
+	HasProduct           bool
+	HasRevision          bool
+	HasLocalManufacturer bool
+	HasAddress           bool
+}
+
+// ParseBluetooth parses a Bluetooth hardware, compatible, device or device
+// instance identifier. Unrecognized components of the identifier are
+// ignored.
+func ParseBluetooth(id string) (Bluetooth, error) {
+	parts := strings.SplitN(id, `\`, 3)
+	bus := strings.ToUpper(parts[0])
+	switch bus {
+	case "BTHENUM", "BTHLE", "BTHLEDEVICE":
+	default:
+		return Bluetooth{}, fmt.Errorf("not a Bluetooth identifier: %s", id)
+	}
+	if len(parts) < 2 || parts[1] == "" {
+		return Bluetooth{}, fmt.Errorf("Bluetooth identifier has no fields: %s", id)
+	}
+
+	b := Bluetooth{Bus: bus}
+
+	specific := parts[1]
+	if strings.HasPrefix(specific, "{") {
+		end := strings.IndexByte(specific, '}')
+		if end < 0 || !isGUID(specific[:end+1]) {
+			return Bluetooth{}, fmt.Errorf("invalid Bluetooth identifier \"%s\": malformed service GUID", id)
+		}
+		b.Service, b.HasService = strings.ToLower(specific[:end+1]), true
+		specific = specific[end+1:]
+	}
+
+	for _, token := range strings.Split(specific, "_") {
+		if err := b.parseToken(token); err != nil {
+			return Bluetooth{}, fmt.Errorf("invalid Bluetooth identifier \"%s\": %v", id, err)
+		}
+	}
+
+	// Look for a device address at the end of the instance component
+	if !b.HasAddress && len(parts) == 3 {
+		instance := parts[2]
+		if i := strings.LastIndexByte(instance, '&'); i >= 0 {
+			instance = instance[i+1:]
+		}
+		for _, token := range strings.Split(instance, "_") {
+			if addr, ok := parseAddressToken(token); ok {
+				b.Address, b.HasAddress = addr, true
+				break
+			}
+		}
+	}
+
+	return b, nil
+}
+
+func (b *Bluetooth) parseToken(token string) error {
+	if value, ok := cutPrefixFold(token, "VID&"); ok {
+		switch len(value) {
+		case 8:
+			v, err := parseHex(value, 8)
+			if err != nil {
+				return fmt.Errorf("vendor: %v", err)
+			}
+			b.VendorIDSource, b.VendorID = uint16(v>>16), uint16(v)
+		case 6:
+			v, err := parseHex(value, 6)
+			if err != nil {
+				return fmt.Errorf("vendor: %v", err)
+			}
+			b.VendorIDSource, b.VendorID = uint16(v>>16), uint16(v)
+		default:
+			return fmt.Errorf("vendor: expected 6 or 8 hexadecimal digits but found \"%s\"", value)
+		}
+		b.HasVendor = true
+		return nil
+	}
+	if value, ok := cutPrefixFold(token, "PID&"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("product: %v", err)
+		}
+		b.ProductID, b.HasProduct = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(token, "REV&"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("revision: %v", err)
+		}
+		b.Revision, b.HasRevision = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(token, "LOCALMFG&"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("local manufacturer: %v", err)
+		}
+		b.LocalManufacturer, b.HasLocalManufacturer = uint16(v), true
+		return nil
+	}
+	if addr, ok := parseAddressToken(token); ok && !b.HasAddress {
+		b.Address, b.HasAddress = addr, true
+	}
+	return nil
+}
+
+// parseAddressToken interprets token as a device address if it consists of
+// exactly 12 hexadecimal digits.
+func parseAddressToken(token string) (BluetoothAddress, bool) {
+	if len(token) != 12 {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(token, 16, 48)
+	if err != nil {
+		return 0, false
+	}
+	return BluetoothAddress(v), true
+}
@@ -0,0 +1,25 @@
+package deviceid
+
+import "fmt"
+
+// validateChars returns an error if id contains a character that is not
+// permitted in a device identification string. The kind is used to describe
+// the identifier in the error message.
+//
+// Device identification strings may only contain characters in the range
+// 0x21 through 0x7F, and may not contain commas. When allowBackslash is false
+// backslashes are also rejected.
+func validateChars(kind, id string, allowBackslash bool) error {
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c <= 0x20 || c > 0x7F:
+			return fmt.Errorf("%s contains an invalid character %#02x at position %d: %s", kind, c, i, id)
+		case c == ',':
+			return fmt.Errorf("%s contains a comma at position %d: %s", kind, i, id)
+		case c == '\\' && !allowBackslash:
+			return fmt.Errorf("%s contains a backslash at position %d: %s", kind, i, id)
+		}
+	}
+	return nil
+}
@@ -3,11 +3,14 @@ package deviceid
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // Device is a device identifier. It is assigned by a device enumerator
 // to a device.
 //
+// A device identifier has the form <enumerator>\<enumerator-specific-device-ID>.
+//
 // https://docs.microsoft.com/en-us/windows-hardware/drivers/install/device-ids
 type Device string
 
@@ -19,5 +22,31 @@ func (id Device) Validate() error {
 	if len(id) > MaxLength {
 		return fmt.Errorf("device identifier exceeds maximum length of %d: %s", MaxLength, id)
 	}
+	if err := validateChars("device identifier", string(id), true); err != nil {
+		return err
+	}
+	enumerator, specific := id.Split()
+	if enumerator == "" {
+		return fmt.Errorf("device identifier does not include an enumerator: %s", id)
+	}
+	if specific == "" {
+		return fmt.Errorf("device identifier does not include an enumerator-specific device ID: %s", id)
+	}
 	return nil
 }
+
+// Split splits id into its enumerator and enumerator-specific components.
+// If id does not contain a backslash the enumerator will be empty.
+func (id Device) Split() (enumerator Enumerator, specific string) {
+	i := strings.IndexByte(string(id), '\\')
+	if i < 0 {
+		return "", string(id)
+	}
+	return Enumerator(id[:i]), string(id[i+1:])
+}
+
+// Enumerator returns the enumerator component of id.
+func (id Device) Enumerator() Enumerator {
+	enumerator, _ := id.Split()
+	return enumerator
+}
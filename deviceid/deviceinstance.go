@@ -3,6 +3,7 @@ package deviceid
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // DeviceInstance is a device instance identifier. It is assigned by the
@@ -20,5 +21,83 @@ func (id DeviceInstance) Validate() error {
 	if len(id) > MaxLength {
 		return fmt.Errorf("device instance identifier exceeds maximum length of %d: %s", MaxLength, id)
 	}
+	_, err := id.Parse()
+	return err
+}
+
+// Parse splits id into its enumerator, enumerator-specific device ID and
+// instance ID components. An error is returned if any of the components are
+// missing or contain invalid characters.
+//
+// For example, parsing PCI\VEN_8086&DEV_15B8\3&11583659&0&FE will return an
+// enumerator of PCI, a device of VEN_8086&DEV_15B8 and an instance of
+// 3&11583659&0&FE.
+func (id DeviceInstance) Parse() (DeviceInstanceParts, error) {
+	if id == "" {
+		return DeviceInstanceParts{}, errors.New("an empty device instance identifier was provided")
+	}
+
+	s := string(id)
+	first := strings.IndexByte(s, '\\')
+	last := strings.LastIndexByte(s, '\\')
+	if first < 0 || first == last {
+		return DeviceInstanceParts{}, fmt.Errorf("device instance identifier does not have the form <enumerator>\\<device>\\<instance>: %s", id)
+	}
+
+	parts := DeviceInstanceParts{
+		Enumerator: Enumerator(s[:first]),
+		Device:     s[first+1 : last],
+		Instance:   Instance(s[last+1:]),
+	}
+
+	if err := parts.Validate(); err != nil {
+		return DeviceInstanceParts{}, err
+	}
+
+	return parts, nil
+}
+
+// DeviceInstanceParts holds the components of a device instance identifier.
+type DeviceInstanceParts struct {
+	Enumerator Enumerator // PCI
+	Device     string     // VEN_8086&DEV_15B8&SUBSYS_00000000&REV_00
+	Instance   Instance   // 3&11583659&0&FE
+}
+
+// Validate returns an error if any of the components are not valid.
+func (p DeviceInstanceParts) Validate() error {
+	if err := p.Enumerator.Validate(); err != nil {
+		return err
+	}
+	if p.Device == "" {
+		return errors.New("an empty enumerator-specific device identifier was provided")
+	}
+	if err := validateChars("enumerator-specific device identifier", p.Device, true); err != nil {
+		return err
+	}
+	if err := p.Instance.Validate(); err != nil {
+		return err
+	}
+	if id := p.DeviceInstance(); len(id) > MaxLength {
+		return fmt.Errorf("device instance identifier exceeds maximum length of %d: %s", MaxLength, id)
+	}
 	return nil
 }
+
+// DeviceID returns the device identifier formed by the enumerator and
+// device components.
+func (p DeviceInstanceParts) DeviceID() Device {
+	return Device(string(p.Enumerator) + `\` + p.Device)
+}
+
+// DeviceInstance returns the device instance identifier formed by the
+// components of p.
+func (p DeviceInstanceParts) DeviceInstance() DeviceInstance {
+	return DeviceInstance(p.String())
+}
+
+// String returns a string representation of the device instance identifier
+// formed by the components of p.
+func (p DeviceInstanceParts) String() string {
+	return string(p.Enumerator) + `\` + p.Device + `\` + string(p.Instance)
+}
@@ -0,0 +1,21 @@
+package deviceid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Enumerator is the name of a device enumerator, such as PCI, USB or ROOT.
+// It is the first component of device and device instance identifiers.
+type Enumerator string
+
+// Validate returns an error if the enumerator name is not valid.
+func (name Enumerator) Validate() error {
+	if name == "" {
+		return errors.New("an empty enumerator name was provided")
+	}
+	if len(name) > MaxLength {
+		return fmt.Errorf("enumerator name exceeds maximum length of %d: %s", MaxLength, name)
+	}
+	return validateChars("enumerator name", string(name), false)
+}
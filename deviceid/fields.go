@@ -0,0 +1,46 @@
+package deviceid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitID splits a hardware, compatible, device or device instance
+// identifier into its enumerator and enumerator-specific components. Any
+// instance component that follows the device component is discarded.
+//
+// If id does not contain a backslash the enumerator will be empty.
+func splitID(id string) (enumerator, specific string) {
+	i := strings.IndexByte(id, '\\')
+	if i < 0 {
+		return "", id
+	}
+	enumerator, specific = id[:i], id[i+1:]
+	if j := strings.IndexByte(specific, '\\'); j >= 0 {
+		specific = specific[:j]
+	}
+	return enumerator, specific
+}
+
+// cutPrefixFold returns s without the provided prefix and true if s begins
+// with prefix when ignoring case. Otherwise it returns s and false.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// parseHex parses s as an unsigned hexadecimal number of exactly the given
+// number of digits.
+func parseHex(s string, digits int) (uint64, error) {
+	if len(s) != digits {
+		return 0, fmt.Errorf("expected %d hexadecimal digits but found \"%s\"", digits, s)
+	}
+	v, err := strconv.ParseUint(s, 16, digits*4)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hexadecimal value \"%s\"", s)
+	}
+	return v, nil
+}
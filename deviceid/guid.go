@@ -0,0 +1,27 @@
+package deviceid
+
+// isGUID returns true if s is a GUID in registry format, such as
+// {a5dcbf10-6530-11d2-901f-00c04fb951ed}. The comparison is not
+// case-sensitive.
+func isGUID(s string) bool {
+	if len(s) != 38 || s[0] != '{' || s[37] != '}' {
+		return false
+	}
+	for i := 1; i < 37; i++ {
+		switch i {
+		case 9, 14, 19, 24:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'A' && c <= 'F') || (c >= 'a' && c <= 'f')
+}
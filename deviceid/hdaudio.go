@@ -0,0 +1,217 @@
+package deviceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HD Audio function group types.
+const (
+	HDAudioFunction = 0x01 // Audio function group
+	HDModemFunction = 0x02 // Vendor-defined modem function group
+)
+
+// HDAudio holds the fields of a High Definition Audio codec function
+// identifier, such as HDAUDIO\FUNC_01&VEN_10EC&DEV_0256&SUBSYS_102808B9&REV_1000.
+//
+// Each field is only meaningful when its corresponding Has flag is set.
+//
+// Note that unlike PCI identifiers, the subsystem vendor ID is the first
+// half of the SUBSYS field.
+type HDAudio struct {
+	Bus                string // HDAUDIO or INTELAUDIO
+	FunctionGroup      uint8  // FUNC_f(2)
+	VendorID           uint16 // VEN_v(4)
+	DeviceID           uint16 // DEV_d(4)
+	SubsystemVendorID  uint16 // SUBSYS_v(4)s(4), first four digits
+	SubsystemID        uint16 // SUBSYS_v(4)s(4), last four digits
+	Revision           uint16 // REV_r(4)
+	ControllerVendorID uint16 // CTLR_VEN_v(4)
+	ControllerDeviceID uint16 // CTLR_DEV_d(4)
+
+	HasFunctionGroup      bool
+	HasVendor             bool
+	HasDevice             bool
+	HasSubsystem          bool
+	HasRevision           bool
+	HasControllerVendorID bool
+	HasControllerDeviceID bool
+}
+
+// ParseHDAudio parses an HD Audio codec function identifier. Device
+// identifiers and device instance identifiers are also accepted, in which
+// case the instance component is ignored.
+func ParseHDAudio(id string) (HDAudio, error) {
+	enumerator, specific := splitID(id)
+	bus := strings.ToUpper(enumerator)
+	if bus != "HDAUDIO" && bus != "INTELAUDIO" {
+		return HDAudio{}, fmt.Errorf("not an HD Audio identifier: %s", id)
+	}
+	if specific == "" {
+		return HDAudio{}, fmt.Errorf("HD Audio identifier has no fields: %s", id)
+	}
+
+	h := HDAudio{Bus: bus}
+	for _, field := range strings.Split(specific, "&") {
+		if err := h.parseField(field); err != nil {
+			return HDAudio{}, fmt.Errorf("invalid HD Audio identifier \"%s\": %v", id, err)
+		}
+	}
+
+	return h, nil
+}
+
+func (h *HDAudio) parseField(field string) error {
+	if value, ok := cutPrefixFold(field, "FUNC_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("function group: %v", err)
+		}
+		h.FunctionGroup, h.HasFunctionGroup = uint8(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "CTLR_VEN_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("controller vendor: %v", err)
+		}
+		h.ControllerVendorID, h.HasControllerVendorID = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "CTLR_DEV_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("controller device: %v", err)
+		}
+		h.ControllerDeviceID, h.HasControllerDeviceID = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "VEN_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("vendor: %v", err)
+		}
+		h.VendorID, h.HasVendor = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "DEV_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("device: %v", err)
+		}
+		h.DeviceID, h.HasDevice = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "SUBSYS_"); ok {
+		v, err := parseHex(value, 8)
+		if err != nil {
+			return fmt.Errorf("subsystem: %v", err)
+		}
+		h.SubsystemVendorID, h.SubsystemID, h.HasSubsystem = uint16(v>>16), uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "REV_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("revision: %v", err)
+		}
+		h.Revision, h.HasRevision = uint16(v), true
+		return nil
+	}
+	return fmt.Errorf("unrecognized field \"%s\"", field)
+}
+
+// String returns the identifier formed by the fields present in h.
+func (h HDAudio) String() string {
+	var fields []string
+	if h.HasFunctionGroup {
+		fields = append(fields, h.fn())
+	}
+	if h.HasControllerVendorID {
+		fields = append(fields, fmt.Sprintf("CTLR_VEN_%04X", h.ControllerVendorID))
+	}
+	if h.HasControllerDeviceID {
+		fields = append(fields, fmt.Sprintf("CTLR_DEV_%04X", h.ControllerDeviceID))
+	}
+	if h.HasVendor {
+		fields = append(fields, h.ven())
+	}
+	if h.HasDevice {
+		fields = append(fields, h.dev())
+	}
+	if h.HasSubsystem {
+		fields = append(fields, h.subsys())
+	}
+	if h.HasRevision {
+		fields = append(fields, h.rev())
+	}
+	return h.id(fields...)
+}
+
+// HardwareIDs returns the ranked list of hardware identifiers that Windows
+// generates for a codec function described by h, from most to least
+// specific.
+//
+// The function group, vendor, device and subsystem fields must be present.
+func (h HDAudio) HardwareIDs() []Hardware {
+	if !h.HasFunctionGroup || !h.HasVendor || !h.HasDevice || !h.HasSubsystem {
+		return nil
+	}
+
+	var ids []Hardware
+	if h.HasRevision {
+		ids = append(ids, Hardware(h.id(h.fn(), h.ven(), h.dev(), h.subsys(), h.rev())))
+	}
+	ids = append(ids, Hardware(h.id(h.fn(), h.ven(), h.dev(), h.subsys())))
+	return ids
+}
+
+// CompatibleIDs returns the ranked list of compatible identifiers that
+// Windows generates for a codec function described by h, from most to
+// least specific.
+//
+// The function group, vendor and device fields must be present.
+func (h HDAudio) CompatibleIDs() []Compatible {
+	if !h.HasFunctionGroup || !h.HasVendor || !h.HasDevice {
+		return nil
+	}
+
+	var ids []Compatible
+	if h.HasRevision {
+		ids = append(ids, Compatible(h.id(h.fn(), h.ven(), h.dev(), h.rev())))
+	}
+	ids = append(ids,
+		Compatible(h.id(h.fn(), h.ven(), h.dev())),
+		Compatible(h.id(h.fn(), h.ven())),
+		Compatible(h.id(h.fn())),
+	)
+	return ids
+}
+
+func (h HDAudio) fn() string {
+	return fmt.Sprintf("FUNC_%02X", h.FunctionGroup)
+}
+
+func (h HDAudio) ven() string {
+	return fmt.Sprintf("VEN_%04X", h.VendorID)
+}
+
+func (h HDAudio) dev() string {
+	return fmt.Sprintf("DEV_%04X", h.DeviceID)
+}
+
+func (h HDAudio) subsys() string {
+	return fmt.Sprintf("SUBSYS_%04X%04X", h.SubsystemVendorID, h.SubsystemID)
+}
+
+func (h HDAudio) rev() string {
+	return fmt.Sprintf("REV_%04X", h.Revision)
+}
+
+func (h HDAudio) id(fields ...string) string {
+	bus := h.Bus
+	if bus == "" {
+		bus = "HDAUDIO"
+	}
+	return bus + `\` + strings.Join(fields, "&")
+}
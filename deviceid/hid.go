@@ -0,0 +1,281 @@
+package deviceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HID holds the fields of a HID hardware identifier, such as
+// HID\VID_046D&PID_C52B&REV_1211&MI_02&Col01 or HID_DEVICE_UP:0001_U:0006.
+//
+// Each field is only meaningful when its corresponding Has flag is set.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/hid/hidclass-hardware-ids-for-top-level-collections
+type HID struct {
+	VendorID   uint16 // VID_v(4)
+	ProductID  uint16 // PID_d(4)
+	Revision   uint16 // REV_r(4)
+	Interface  uint8  // MI_z(2)
+	Collection uint8  // Colb(2)
+	UsagePage  uint16 // UP:p(4)
+	Usage      uint16 // U:u(4)
+
+	HasVendor     bool
+	HasProduct    bool
+	HasRevision   bool
+	HasInterface  bool
+	HasCollection bool
+	HasUsage      bool // UsagePage and Usage are present
+}
+
+// hidSystemDevices maps top-level collection usages to the system-supplied
+// HID_DEVICE_SYSTEM_* identifiers that Windows generates for them.
+var hidSystemDevices = []struct {
+	UsagePage uint16
+	Usage     uint16
+	ID        string
+}{
+	{0x01, 0x02, "HID_DEVICE_SYSTEM_MOUSE"},
+	{0x01, 0x04, "HID_DEVICE_SYSTEM_GAME"},
+	{0x01, 0x05, "HID_DEVICE_SYSTEM_GAME"},
+	{0x01, 0x06, "HID_DEVICE_SYSTEM_KEYBOARD"},
+	{0x01, 0x07, "HID_DEVICE_SYSTEM_KEYBOARD"},
+	{0x01, 0x80, "HID_DEVICE_SYSTEM_CONTROL"},
+	{0x0C, 0x01, "HID_DEVICE_SYSTEM_CONSUMER"},
+}
+
+// ParseHID parses a HID hardware identifier. Device identifiers and device
+// instance identifiers are also accepted, in which case the instance
+// component is ignored.
+//
+// The following forms are recognized:
+//
+//	HID\VID_v(4)&PID_d(4)&REV_r(4)&MI_z(2)&Colb(2)
+//	HID\VID_v(4)&UP:p(4)_U:u(4)
+//	HID_DEVICE_SYSTEM_KEYBOARD
+//	HID_DEVICE_UP:p(4)_U:u(4)
+//	HID_DEVICE
+func ParseHID(id string) (HID, error) {
+	if rest, ok := cutPrefixFold(id, "HID_DEVICE"); ok {
+		return parseHIDDevice(id, rest)
+	}
+
+	enumerator, specific := splitID(id)
+	if !strings.EqualFold(enumerator, "HID") {
+		return HID{}, fmt.Errorf("not a HID identifier: %s", id)
+	}
+	if specific == "" {
+		return HID{}, fmt.Errorf("HID identifier has no fields: %s", id)
+	}
+
+	var h HID
+	for _, field := range strings.Split(specific, "&") {
+		if err := h.parseField(field); err != nil {
+			return HID{}, fmt.Errorf("invalid HID identifier \"%s\": %v", id, err)
+		}
+	}
+
+	if h.HasProduct && !h.HasVendor {
+		return HID{}, fmt.Errorf("invalid HID identifier \"%s\": a product ID was provided without a vendor ID", id)
+	}
+
+	return h, nil
+}
+
+func parseHIDDevice(id, rest string) (HID, error) {
+	if rest == "" {
+		return HID{}, nil
+	}
+	if usage, ok := cutPrefixFold(rest, "_"); ok {
+		for _, system := range hidSystemDevices {
+			if strings.EqualFold(id, system.ID) {
+				return HID{UsagePage: system.UsagePage, Usage: system.Usage, HasUsage: true}, nil
+			}
+		}
+		var h HID
+		if err := h.parseField(usage); err == nil && h.HasUsage {
+			return h, nil
+		}
+	}
+	return HID{}, fmt.Errorf("invalid HID identifier: %s", id)
+}
+
+func (h *HID) parseField(field string) error {
+	if value, ok := cutPrefixFold(field, "VID_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("vendor: %v", err)
+		}
+		h.VendorID, h.HasVendor = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "PID_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("product: %v", err)
+		}
+		h.ProductID, h.HasProduct = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "REV_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("revision: %v", err)
+		}
+		h.Revision, h.HasRevision = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "MI_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("interface: %v", err)
+		}
+		h.Interface, h.HasInterface = uint8(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "Col"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("collection: %v", err)
+		}
+		h.Collection, h.HasCollection = uint8(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "UP:"); ok {
+		page, usage, found := strings.Cut(value, "_")
+		if !found {
+			return fmt.Errorf("usage: missing usage in \"%s\"", field)
+		}
+		usage, ok := cutPrefixFold(usage, "U:")
+		if !ok {
+			return fmt.Errorf("usage: missing usage in \"%s\"", field)
+		}
+		p, err := parseHex(page, 4)
+		if err != nil {
+			return fmt.Errorf("usage page: %v", err)
+		}
+		u, err := parseHex(usage, 4)
+		if err != nil {
+			return fmt.Errorf("usage: %v", err)
+		}
+		h.UsagePage, h.Usage, h.HasUsage = uint16(p), uint16(u), true
+		return nil
+	}
+	return fmt.Errorf("unrecognized field \"%s\"", field)
+}
+
+// String returns the identifier formed by the fields present in h.
+func (h HID) String() string {
+	if !h.HasVendor && !h.HasProduct && !h.HasRevision && !h.HasInterface && !h.HasCollection {
+		if h.HasUsage {
+			return "HID_DEVICE_" + h.up()
+		}
+		return "HID_DEVICE"
+	}
+
+	var fields []string
+	if h.HasVendor {
+		fields = append(fields, h.vid())
+	}
+	if h.HasProduct {
+		fields = append(fields, h.pid())
+	}
+	if h.HasRevision {
+		fields = append(fields, h.rev())
+	}
+	if h.HasInterface {
+		fields = append(fields, h.mi())
+	}
+	if h.HasCollection {
+		fields = append(fields, h.col())
+	}
+	if h.HasUsage {
+		fields = append(fields, h.up())
+	}
+	return hidID(fields...)
+}
+
+// SystemID returns the HID_DEVICE_SYSTEM_* identifier that Windows generates
+// for the top-level collection usage in h. It returns an empty string if the
+// usage has no system identifier.
+func (h HID) SystemID() Hardware {
+	if !h.HasUsage {
+		return ""
+	}
+	for _, system := range hidSystemDevices {
+		if system.UsagePage == h.UsagePage && system.Usage == h.Usage {
+			return Hardware(system.ID)
+		}
+	}
+	return ""
+}
+
+// HardwareIDs returns the ranked list of hardware identifiers that Windows
+// generates for a HID top-level collection described by h, from most to
+// least specific.
+//
+// Identifiers that depend on missing fields are omitted. The HID_DEVICE
+// identifier is always included last.
+func (h HID) HardwareIDs() []Hardware {
+	var ids []Hardware
+	if h.HasVendor && h.HasProduct {
+		var suffix []string
+		if h.HasInterface {
+			suffix = append(suffix, h.mi())
+		}
+		if h.HasCollection {
+			suffix = append(suffix, h.col())
+		}
+		if h.HasRevision {
+			ids = append(ids, Hardware(hidID(append([]string{h.vid(), h.pid(), h.rev()}, suffix...)...)))
+		}
+		ids = append(ids, Hardware(hidID(append([]string{h.vid(), h.pid()}, suffix...)...)))
+	}
+	if h.HasUsage {
+		if h.HasVendor {
+			ids = append(ids, Hardware(hidID(h.vid(), h.up())))
+		}
+		if system := h.SystemID(); system != "" {
+			ids = append(ids, system)
+		}
+		ids = append(ids, Hardware("HID_DEVICE_"+h.up()))
+	}
+	return append(ids, Hardware("HID_DEVICE"))
+}
+
+// CompatibleIDs returns the list of compatible identifiers that Windows
+// generates for a HID top-level collection described by h.
+//
+// The HID class driver does not report compatible identifiers for top-level
+// collections, so the returned list is always empty.
+func (h HID) CompatibleIDs() []Compatible {
+	return nil
+}
+
+func (h HID) vid() string {
+	return fmt.Sprintf("VID_%04X", h.VendorID)
+}
+
+func (h HID) pid() string {
+	return fmt.Sprintf("PID_%04X", h.ProductID)
+}
+
+func (h HID) rev() string {
+	return fmt.Sprintf("REV_%04X", h.Revision)
+}
+
+func (h HID) mi() string {
+	return fmt.Sprintf("MI_%02X", h.Interface)
+}
+
+func (h HID) col() string {
+	return fmt.Sprintf("Col%02X", h.Collection)
+}
+
+func (h HID) up() string {
+	return fmt.Sprintf("UP:%04X_U:%04X", h.UsagePage, h.Usage)
+}
+
+func hidID(fields ...string) string {
+	return `HID\` + strings.Join(fields, "&")
+}
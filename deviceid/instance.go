@@ -19,5 +19,5 @@ func (id Instance) Validate() error {
 	if len(id) > MaxLength {
 		return fmt.Errorf("instance identifier exceeds maximum length of %d: %s", MaxLength, id)
 	}
-	return nil
+	return validateChars("instance identifier", string(id), false)
 }
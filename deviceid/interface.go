@@ -0,0 +1,122 @@
+package deviceid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Interface is a device interface path, also known as a symbolic link name.
+// It identifies an instance of a device interface class that is exposed by
+// a device, such as:
+//
+//	\\?\USB#VID_046D&PID_C52B#5&1a2b3c&0&2#{a5dcbf10-6530-11d2-901f-00c04fb951ed}
+//
+// Interface paths are formed by replacing the backslashes in a device
+// instance identifier with number signs and appending the interface class
+// GUID and an optional reference string. Windows does not preserve the case
+// of the device instance identifier within interface paths, so comparisons
+// should not be case-sensitive.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/overview-of-device-interface-classes
+type Interface string
+
+// interfacePrefixes are the prefixes that may precede an interface path.
+var interfacePrefixes = []string{`\\?\`, `\\.\`, `\??\`}
+
+// Validate returns an error if the interface path is not valid.
+func (path Interface) Validate() error {
+	_, err := path.Parse()
+	return err
+}
+
+// Parse splits path into its device instance identifier, interface class
+// GUID and reference string components.
+func (path Interface) Parse() (InterfaceParts, error) {
+	if path == "" {
+		return InterfaceParts{}, errors.New("an empty interface path was provided")
+	}
+
+	s := string(path)
+	prefixed := false
+	for _, prefix := range interfacePrefixes {
+		if strings.HasPrefix(s, prefix) {
+			s, prefixed = s[len(prefix):], true
+			break
+		}
+	}
+	if !prefixed {
+		return InterfaceParts{}, fmt.Errorf("interface path does not begin with %s: %s", interfacePrefixes[0], path)
+	}
+
+	var parts InterfaceParts
+	if i := strings.IndexByte(s, '\\'); i >= 0 {
+		s, parts.Reference = s[:i], s[i+1:]
+	}
+
+	i := strings.LastIndexByte(s, '#')
+	if i < 0 {
+		return InterfaceParts{}, fmt.Errorf("interface path does not include an interface class GUID: %s", path)
+	}
+	device, class := s[:i], s[i+1:]
+
+	// Only the first two separators belong to the device instance
+	// identifier. Instance identifiers may contain number signs of their
+	// own.
+	device = strings.Replace(device, "#", `\`, 2)
+
+	parts.DeviceInstance = DeviceInstance(device)
+	parts.Class = class
+
+	if err := parts.Validate(); err != nil {
+		return InterfaceParts{}, fmt.Errorf("invalid interface path \"%s\": %v", path, err)
+	}
+
+	return parts, nil
+}
+
+// DeviceInstance returns the device instance identifier of the device that
+// exposes the interface.
+func (path Interface) DeviceInstance() (DeviceInstance, error) {
+	parts, err := path.Parse()
+	return parts.DeviceInstance, err
+}
+
+// InterfaceParts holds the components of a device interface path.
+type InterfaceParts struct {
+	DeviceInstance DeviceInstance // USB\VID_046D&PID_C52B\5&1a2b3c&0&2
+	Class          string         // {a5dcbf10-6530-11d2-901f-00c04fb951ed}
+	Reference      string         // Optional reference string
+}
+
+// Validate returns an error if any of the components are not valid.
+func (p InterfaceParts) Validate() error {
+	if _, err := p.DeviceInstance.Parse(); err != nil {
+		return err
+	}
+	if !isGUID(p.Class) {
+		return fmt.Errorf("interface class is not a valid GUID: %s", p.Class)
+	}
+	if p.Reference != "" {
+		if err := validateChars("interface reference string", p.Reference, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Interface returns the device interface path formed by the components
+// of p.
+func (p InterfaceParts) Interface() Interface {
+	return Interface(p.String())
+}
+
+// String returns a string representation of the device interface path
+// formed by the components of p.
+func (p InterfaceParts) String() string {
+	s := interfacePrefixes[0] + strings.Replace(string(p.DeviceInstance), `\`, "#", 2) + "#" + p.Class
+	if p.Reference != "" {
+		s += `\` + p.Reference
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+package deviceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Monitor holds the fields of a monitor hardware identifier, such as
+// MONITOR\DEL4093. The vendor is the three-letter PNP manufacturer ID and the
+// product is the product code reported in the monitor's EDID.
+type Monitor struct {
+	Vendor  string // DEL
+	Product uint16 // 4093
+}
+
+// ParseMonitor parses a monitor hardware identifier. Device identifiers and
+// device instance identifiers, which use the DISPLAY enumerator, are also
+// accepted, in which case the instance component is ignored.
+func ParseMonitor(id string) (Monitor, error) {
+	enumerator, specific := splitID(id)
+	if !strings.EqualFold(enumerator, "MONITOR") && !strings.EqualFold(enumerator, "DISPLAY") {
+		return Monitor{}, fmt.Errorf("not a monitor identifier: %s", id)
+	}
+	if len(specific) != 7 {
+		return Monitor{}, fmt.Errorf("invalid monitor identifier \"%s\": expected a three-letter vendor ID and four-digit product code", id)
+	}
+	vendor, product, err := splitPNPID(specific)
+	if err != nil {
+		return Monitor{}, fmt.Errorf("invalid monitor identifier \"%s\": %v", id, err)
+	}
+	return Monitor{Vendor: vendor, Product: product}, nil
+}
+
+// ID returns the combined vendor and product ID, such as DEL4093.
+func (m Monitor) ID() string {
+	return fmt.Sprintf("%s%04X", m.Vendor, m.Product)
+}
+
+// String returns the hardware identifier formed by the fields of m.
+func (m Monitor) String() string {
+	return `MONITOR\` + m.ID()
+}
+
+// HardwareIDs returns the hardware identifiers that Windows generates for
+// a monitor described by m.
+func (m Monitor) HardwareIDs() []Hardware {
+	return []Hardware{Hardware(m.String())}
+}
+
+// CompatibleIDs returns the compatible identifiers that Windows generates
+// for a monitor described by m.
+func (m Monitor) CompatibleIDs() []Compatible {
+	return []Compatible{"*PNP09FF"}
+}
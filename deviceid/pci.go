@@ -0,0 +1,223 @@
+package deviceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PCI holds the fields of a PCI hardware or compatible identifier.
+//
+// Each field is only meaningful when its corresponding Has flag is set.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/identifiers-for-pci-devices
+type PCI struct {
+	VendorID          uint16 // VEN_v(4)
+	DeviceID          uint16 // DEV_d(4)
+	SubsystemID       uint16 // SUBSYS_s(4)n(4), first four digits
+	SubsystemVendorID uint16 // SUBSYS_s(4)n(4), last four digits
+	Revision          uint8  // REV_r(2)
+	BaseClass         uint8  // CC_c(2)s(2)p(2), first two digits
+	SubClass          uint8  // CC_c(2)s(2)p(2), middle two digits
+	ProgIf            uint8  // CC_c(2)s(2)p(2), last two digits
+
+	HasVendor    bool
+	HasDevice    bool
+	HasSubsystem bool
+	HasRevision  bool
+	HasClass     bool // BaseClass and SubClass are present
+	HasProgIf    bool // ProgIf is present
+}
+
+// ParsePCI parses a PCI hardware or compatible identifier such as
+// PCI\VEN_10DE&DEV_1EB8&SUBSYS_12A210DE&REV_A1. Device identifiers and
+// device instance identifiers are also accepted, in which case the instance
+// component is ignored.
+func ParsePCI(id string) (PCI, error) {
+	enumerator, specific := splitID(id)
+	if !strings.EqualFold(enumerator, "PCI") {
+		return PCI{}, fmt.Errorf("not a PCI identifier: %s", id)
+	}
+	if specific == "" {
+		return PCI{}, fmt.Errorf("PCI identifier has no fields: %s", id)
+	}
+
+	var p PCI
+	for _, field := range strings.Split(specific, "&") {
+		if err := p.parseField(field); err != nil {
+			return PCI{}, fmt.Errorf("invalid PCI identifier \"%s\": %v", id, err)
+		}
+	}
+
+	if p.HasDevice && !p.HasVendor {
+		return PCI{}, fmt.Errorf("invalid PCI identifier \"%s\": a device ID was provided without a vendor ID", id)
+	}
+
+	return p, nil
+}
+
+func (p *PCI) parseField(field string) error {
+	if value, ok := cutPrefixFold(field, "VEN_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("vendor: %v", err)
+		}
+		p.VendorID, p.HasVendor = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "DEV_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("device: %v", err)
+		}
+		p.DeviceID, p.HasDevice = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "SUBSYS_"); ok {
+		v, err := parseHex(value, 8)
+		if err != nil {
+			return fmt.Errorf("subsystem: %v", err)
+		}
+		p.SubsystemID, p.SubsystemVendorID, p.HasSubsystem = uint16(v>>16), uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "REV_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("revision: %v", err)
+		}
+		p.Revision, p.HasRevision = uint8(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "CC_"); ok {
+		switch len(value) {
+		case 4:
+			v, err := parseHex(value, 4)
+			if err != nil {
+				return fmt.Errorf("class code: %v", err)
+			}
+			p.BaseClass, p.SubClass, p.HasClass = uint8(v>>8), uint8(v), true
+		case 6:
+			v, err := parseHex(value, 6)
+			if err != nil {
+				return fmt.Errorf("class code: %v", err)
+			}
+			p.BaseClass, p.SubClass, p.ProgIf = uint8(v>>16), uint8(v>>8), uint8(v)
+			p.HasClass, p.HasProgIf = true, true
+		default:
+			return fmt.Errorf("class code: expected 4 or 6 hexadecimal digits but found \"%s\"", value)
+		}
+		return nil
+	}
+	return fmt.Errorf("unrecognized field \"%s\"", field)
+}
+
+// String returns the identifier formed by the fields present in p.
+func (p PCI) String() string {
+	var fields []string
+	if p.HasVendor {
+		fields = append(fields, p.ven())
+	}
+	if p.HasDevice {
+		fields = append(fields, p.dev())
+	}
+	if p.HasSubsystem {
+		fields = append(fields, p.subsys())
+	}
+	if p.HasRevision {
+		fields = append(fields, p.rev())
+	}
+	if p.HasClass {
+		if p.HasProgIf {
+			fields = append(fields, p.ccFull())
+		} else {
+			fields = append(fields, p.ccShort())
+		}
+	}
+	return pciID(fields...)
+}
+
+// HardwareIDs returns the ranked list of hardware identifiers that Windows
+// generates for a PCI function described by p, from most to least specific.
+//
+// The vendor and device fields must be present. Identifiers that depend on
+// missing fields are omitted.
+func (p PCI) HardwareIDs() []Hardware {
+	if !p.HasVendor || !p.HasDevice {
+		return nil
+	}
+
+	var ids []Hardware
+	if p.HasSubsystem {
+		if p.HasRevision {
+			ids = append(ids, Hardware(pciID(p.ven(), p.dev(), p.subsys(), p.rev())))
+		}
+		ids = append(ids, Hardware(pciID(p.ven(), p.dev(), p.subsys())))
+	}
+	if p.HasClass {
+		if p.HasProgIf {
+			ids = append(ids, Hardware(pciID(p.ven(), p.dev(), p.ccFull())))
+		}
+		ids = append(ids, Hardware(pciID(p.ven(), p.dev(), p.ccShort())))
+	}
+	return ids
+}
+
+// CompatibleIDs returns the ranked list of compatible identifiers that
+// Windows generates for a PCI function described by p, from most to least
+// specific.
+//
+// The vendor and device fields must be present. Identifiers that depend on
+// missing fields are omitted.
+func (p PCI) CompatibleIDs() []Compatible {
+	if !p.HasVendor || !p.HasDevice {
+		return nil
+	}
+
+	var ids []Compatible
+	if p.HasRevision {
+		ids = append(ids, Compatible(pciID(p.ven(), p.dev(), p.rev())))
+	}
+	ids = append(ids, Compatible(pciID(p.ven(), p.dev())))
+	if p.HasClass {
+		if p.HasProgIf {
+			ids = append(ids, Compatible(pciID(p.ven(), p.ccFull())))
+		}
+		ids = append(ids, Compatible(pciID(p.ven(), p.ccShort())))
+	}
+	ids = append(ids, Compatible(pciID(p.ven())))
+	if p.HasClass {
+		if p.HasProgIf {
+			ids = append(ids, Compatible(pciID(p.ccFull())))
+		}
+		ids = append(ids, Compatible(pciID(p.ccShort())))
+	}
+	return ids
+}
+
+func (p PCI) ven() string {
+	return fmt.Sprintf("VEN_%04X", p.VendorID)
+}
+
+func (p PCI) dev() string {
+	return fmt.Sprintf("DEV_%04X", p.DeviceID)
+}
+
+func (p PCI) subsys() string {
+	return fmt.Sprintf("SUBSYS_%04X%04X", p.SubsystemID, p.SubsystemVendorID)
+}
+
+func (p PCI) rev() string {
+	return fmt.Sprintf("REV_%02X", p.Revision)
+}
+
+func (p PCI) ccFull() string {
+	return fmt.Sprintf("CC_%02X%02X%02X", p.BaseClass, p.SubClass, p.ProgIf)
+}
+
+func (p PCI) ccShort() string {
+	return fmt.Sprintf("CC_%02X%02X", p.BaseClass, p.SubClass)
+}
+
+func pciID(fields ...string) string {
+	return `PCI\` + strings.Join(fields, "&")
+}
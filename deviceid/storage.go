@@ -0,0 +1,322 @@
+package deviceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Storage buses recognized by ParseStorage.
+const (
+	StorageSCSI    = "SCSI"
+	StorageIDE     = "IDE"
+	StorageUSBSTOR = "USBSTOR"
+	StorageVolume  = "STORAGE"
+)
+
+// storageTypes maps SCSI device type names to the generic type identifiers
+// that Windows generates for them.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/identifiers-for-scsi-devices
+var storageTypes = []struct {
+	Type    string
+	Generic string
+}{
+	{"Disk", "GenDisk"},
+	{"Sequential", "GenSequential"},
+	{"Printer", "GenPrinter"},
+	{"Processor", "GenProcessor"},
+	{"Worm", "GenWorm"},
+	{"CdRom", "GenCdRom"},
+	{"Scanner", "GenScanner"},
+	{"Optical", "GenOptical"},
+	{"Changer", "ScsiChanger"},
+	{"Net", "ScsiNet"},
+	{"ASCIT8", "ScsiASCIT8"},
+	{"Array", "ScsiArray"},
+	{"Enclosure", "ScsiEnclosure"},
+	{"RBC", "ScsiRBC"},
+	{"CardReader", "ScsiCardReader"},
+	{"Bridge", "ScsiBridge"},
+	{"Other", "ScsiOther"},
+}
+
+// Storage holds the fields of a storage device identifier, such as
+// SCSI\DiskSamsung_SSD_970_EVO_1B2QEXE7,
+// IDE\DiskWDC_WD10EZEX-08WN4A0_____01.01A01 or STORAGE\Volume.
+//
+// SCSI and USBSTOR identifiers are built from fixed-width vendor (8),
+// product (16) and revision fields taken from the device's inquiry data.
+// IDE identifiers are built from the model (up to 40 characters) and the
+// 8 character firmware revision reported by the drive. Spaces in these fields are
+// replaced with underscores and the fields are padded with underscores.
+//
+// The fields of Storage hold the decoded values, with padding removed and
+// underscores converted back to spaces. For IDE devices the vendor is the
+// first word of the model, if the model contains more than one word.
+//
+// NVMe disks are reported through the SCSI bus with a vendor of NVMe.
+type Storage struct {
+	Bus      string // SCSI, IDE, USBSTOR or STORAGE
+	Type     string // Disk, CdRom, Volume
+	Vendor   string
+	Product  string
+	Revision string
+}
+
+// ParseStorage parses a SCSI, IDE, USBSTOR or STORAGE hardware or compatible
+// identifier. Device identifiers and device instance identifiers are also
+// accepted, in which case the instance component is ignored. Generic type
+// identifiers such as GenDisk are accepted without an enumerator.
+func ParseStorage(id string) (Storage, error) {
+	enumerator, specific := splitID(id)
+
+	if enumerator == "" {
+		if t := storageTypeForGeneric(specific); t != "" {
+			return Storage{Type: t}, nil
+		}
+		return Storage{}, fmt.Errorf("not a storage identifier: %s", id)
+	}
+
+	bus := strings.ToUpper(enumerator)
+	switch bus {
+	case StorageSCSI, StorageIDE, StorageUSBSTOR:
+	case StorageVolume:
+		if len(specific) < len("Volume") || !strings.EqualFold(specific[:len("Volume")], "Volume") {
+			return Storage{}, fmt.Errorf("invalid storage identifier \"%s\": unrecognized device \"%s\"", id, specific)
+		}
+		return Storage{Bus: bus, Type: specific}, nil
+	default:
+		return Storage{}, fmt.Errorf("not a storage identifier: %s", id)
+	}
+
+	if specific == "" {
+		return Storage{}, fmt.Errorf("storage identifier has no fields: %s", id)
+	}
+
+	// Generic types and SCSI\RAW
+	if t := storageTypeForGeneric(specific); t != "" {
+		return Storage{Bus: bus, Type: t}, nil
+	}
+	if strings.EqualFold(specific, "RAW") {
+		return Storage{Bus: bus, Type: "RAW"}, nil
+	}
+
+	// Device instance style: Disk&Ven_NVMe&Prod_Samsung_SSD_970&Rev_2B2Q
+	if t, rest, ok := cutStorageType(specific); ok && strings.HasPrefix(rest, "&") {
+		s := Storage{Bus: bus, Type: t}
+		for _, field := range strings.Split(rest[1:], "&") {
+			if value, ok := cutPrefixFold(field, "Ven_"); ok {
+				s.Vendor = unpadStorage(value)
+			} else if value, ok := cutPrefixFold(field, "Prod_"); ok {
+				s.Product = unpadStorage(value)
+			} else if value, ok := cutPrefixFold(field, "Rev_"); ok {
+				s.Revision = unpadStorage(value)
+			} else {
+				return Storage{}, fmt.Errorf("invalid storage identifier \"%s\": unrecognized field \"%s\"", id, field)
+			}
+		}
+		return s, nil
+	}
+
+	// SCSI and USBSTOR identifiers without a type start with the vendor,
+	// which may itself begin with a type name, as in NETAPP. Only treat a
+	// prefix as a type when the fields after it are complete, or when the
+	// identifier is too short to hold the untyped vendor/product/revision
+	// layout.
+	s := Storage{Bus: bus}
+	rest := specific
+	if t, r, ok := cutStorageType(specific); ok {
+		if bus == StorageIDE || len(r) >= 8+16 || len(specific) < 8+16 {
+			s.Type, rest = t, r
+		}
+	}
+
+	if rest == "" {
+		return s, nil
+	}
+
+	if bus == StorageIDE {
+		// The revision occupies the last 8 characters. Windows does not
+		// always pad the model to its full width of 40 characters.
+		model := rest
+		if len(rest) > 8 {
+			model, s.Revision = rest[:len(rest)-8], rest[len(rest)-8:]
+		}
+		model = unpadStorage(model)
+		if vendor, product, found := strings.Cut(model, " "); found {
+			s.Vendor, s.Product = vendor, strings.TrimSpace(product)
+		} else {
+			s.Product = model
+		}
+		s.Revision = unpadStorage(s.Revision)
+		return s, nil
+	}
+
+	switch {
+	case len(rest) > 24:
+		s.Vendor, s.Product, s.Revision = rest[:8], rest[8:24], rest[24:]
+	case len(rest) > 8:
+		s.Vendor, s.Product = rest[:8], rest[8:]
+	default:
+		s.Vendor = rest
+	}
+	s.Vendor, s.Product, s.Revision = unpadStorage(s.Vendor), unpadStorage(s.Product), unpadStorage(s.Revision)
+
+	return s, nil
+}
+
+// NVMe returns true if s describes an NVMe disk.
+func (s Storage) NVMe() bool {
+	return strings.EqualFold(s.Vendor, "NVMe")
+}
+
+// Generic returns the generic type identifier for the device type of s,
+// such as GenDisk. It returns an empty string if the type has no generic
+// identifier.
+func (s Storage) Generic() string {
+	for _, entry := range storageTypes {
+		if strings.EqualFold(entry.Type, s.Type) {
+			return entry.Generic
+		}
+	}
+	return ""
+}
+
+// String returns the most specific identifier that can be formed from the
+// fields of s.
+func (s Storage) String() string {
+	if ids := s.HardwareIDs(); len(ids) > 0 {
+		return string(ids[0])
+	}
+	if s.Type == "" && (s.Vendor != "" || s.Product != "") {
+		switch s.Bus {
+		case StorageSCSI, StorageUSBSTOR:
+			v, p, r := padStorage(s.Vendor, 8), padStorage(s.Product, 16), padStorage(s.Revision, 4)
+			return s.Bus + `\` + v + p + r[:1]
+		}
+	}
+	if s.Bus != "" {
+		return s.Bus + `\` + s.Type
+	}
+	return s.Generic()
+}
+
+// HardwareIDs returns the ranked list of hardware identifiers that Windows
+// generates for a storage device described by s, from most to least
+// specific.
+//
+// The bus and type fields must be present. For buses other than STORAGE the
+// vendor or product must also be present.
+func (s Storage) HardwareIDs() []Hardware {
+	if s.Bus == "" || s.Type == "" {
+		return nil
+	}
+	if s.Bus != StorageVolume && s.Vendor == "" && s.Product == "" {
+		return nil
+	}
+
+	var ids []Hardware
+	switch s.Bus {
+	case StorageVolume:
+		return []Hardware{Hardware(s.Bus + `\` + s.Type)}
+	case StorageIDE:
+		model := s.Product
+		if s.Vendor != "" {
+			model = s.Vendor + " " + s.Product
+		}
+		v, r := padStorage(model, 40), padStorage(s.Revision, 8)
+		ids = append(ids,
+			Hardware(`IDE\`+s.Type+v+r),
+			Hardware(`IDE\`+v+r),
+			Hardware(`IDE\`+s.Type+v),
+			Hardware(v+r),
+		)
+	case StorageSCSI, StorageUSBSTOR:
+		v, p, r := padStorage(s.Vendor, 8), padStorage(s.Product, 16), padStorage(s.Revision, 4)
+		prefix := s.Bus + `\`
+		ids = append(ids,
+			Hardware(prefix+s.Type+v+p+r),
+			Hardware(prefix+s.Type+v+p),
+			Hardware(prefix+s.Type+v),
+			Hardware(prefix+v+p+r[:1]),
+			Hardware(v+p+r[:1]),
+		)
+		if generic := s.Generic(); generic != "" && s.Bus == StorageUSBSTOR {
+			ids = append(ids, Hardware(prefix+generic))
+		}
+	default:
+		return nil
+	}
+	if generic := s.Generic(); generic != "" {
+		ids = append(ids, Hardware(generic))
+	}
+	return ids
+}
+
+// CompatibleIDs returns the ranked list of compatible identifiers that
+// Windows generates for a storage device described by s, from most to least
+// specific.
+//
+// The bus and type fields must be present.
+func (s Storage) CompatibleIDs() []Compatible {
+	if s.Bus == "" || s.Type == "" {
+		return nil
+	}
+
+	switch s.Bus {
+	case StorageIDE:
+		if generic := s.Generic(); generic != "" {
+			return []Compatible{Compatible(generic)}
+		}
+	case StorageSCSI, StorageUSBSTOR:
+		if strings.EqualFold(s.Type, "RAW") {
+			return []Compatible{Compatible(s.Bus + `\RAW`)}
+		}
+		ids := []Compatible{
+			Compatible(s.Bus + `\` + s.Type),
+			Compatible(s.Bus + `\RAW`),
+		}
+		if generic := s.Generic(); generic != "" && s.Bus == StorageUSBSTOR {
+			ids = append(ids, Compatible(generic))
+		}
+		return ids
+	}
+	return nil
+}
+
+// cutStorageType removes a known device type prefix from s.
+func cutStorageType(s string) (t, rest string, ok bool) {
+	for _, entry := range storageTypes {
+		if rest, ok := cutPrefixFold(s, entry.Type); ok {
+			return entry.Type, rest, true
+		}
+	}
+	return "", s, false
+}
+
+// storageTypeForGeneric returns the device type for a generic type
+// identifier such as GenDisk.
+func storageTypeForGeneric(s string) string {
+	for _, entry := range storageTypes {
+		if strings.EqualFold(entry.Generic, s) {
+			return entry.Type
+		}
+	}
+	return ""
+}
+
+// unpadStorage removes underscore padding from a storage identifier field
+// and converts the remaining underscores to spaces.
+func unpadStorage(s string) string {
+	return strings.TrimSpace(strings.ReplaceAll(strings.TrimRight(s, "_"), "_", " "))
+}
+
+// padStorage converts spaces in s to underscores and pads or truncates it
+// to the given width.
+func padStorage(s string, width int) string {
+	s = strings.ReplaceAll(s, " ", "_")
+	if len(s) >= width {
+		return s[:width]
+	}
+	return s + strings.Repeat("_", width-len(s))
+}
@@ -0,0 +1,253 @@
+package deviceid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// USB holds the fields of a USB hardware or compatible identifier.
+//
+// Each field is only meaningful when its corresponding Has flag is set.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/standard-usb-identifiers
+type USB struct {
+	VendorID  uint16 // VID_v(4)
+	ProductID uint16 // PID_d(4)
+	Revision  uint16 // REV_r(4)
+	Interface uint8  // MI_z(2)
+	Class     uint8  // Class_c(2) or DevClass_c(2)
+	SubClass  uint8  // SubClass_s(2)
+	Protocol  uint8  // Prot_p(2)
+
+	// DeviceClass is true when the class fields were taken from the device
+	// descriptor of a composite device (DevClass_c(2)) instead of an
+	// interface descriptor (Class_c(2)). Windows only generates these
+	// identifiers for composite devices.
+	DeviceClass bool
+
+	// Composite is true for the USB\COMPOSITE compatible identifier.
+	Composite bool
+
+	HasVendor    bool
+	HasProduct   bool
+	HasRevision  bool
+	HasInterface bool
+	HasClass     bool
+	HasSubClass  bool
+	HasProtocol  bool
+}
+
+// ParseUSB parses a USB hardware or compatible identifier such as
+// USB\VID_046D&PID_C52B&REV_1211&MI_02 or USB\Class_03&SubClass_01&Prot_02.
+// Device identifiers and device instance identifiers are also accepted, in
+// which case the instance component is ignored.
+func ParseUSB(id string) (USB, error) {
+	enumerator, specific := splitID(id)
+	if !strings.EqualFold(enumerator, "USB") {
+		return USB{}, fmt.Errorf("not a USB identifier: %s", id)
+	}
+	if specific == "" {
+		return USB{}, fmt.Errorf("USB identifier has no fields: %s", id)
+	}
+
+	if strings.EqualFold(specific, "COMPOSITE") {
+		return USB{Composite: true}, nil
+	}
+
+	var u USB
+	for _, field := range strings.Split(specific, "&") {
+		if err := u.parseField(field); err != nil {
+			return USB{}, fmt.Errorf("invalid USB identifier \"%s\": %v", id, err)
+		}
+	}
+
+	if u.HasProduct && !u.HasVendor {
+		return USB{}, fmt.Errorf("invalid USB identifier \"%s\": a product ID was provided without a vendor ID", id)
+	}
+
+	return u, nil
+}
+
+func (u *USB) parseField(field string) error {
+	if value, ok := cutPrefixFold(field, "VID_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("vendor: %v", err)
+		}
+		u.VendorID, u.HasVendor = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "PID_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("product: %v", err)
+		}
+		u.ProductID, u.HasProduct = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "REV_"); ok {
+		v, err := parseHex(value, 4)
+		if err != nil {
+			return fmt.Errorf("revision: %v", err)
+		}
+		u.Revision, u.HasRevision = uint16(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "MI_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("interface: %v", err)
+		}
+		u.Interface, u.HasInterface = uint8(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "DevClass_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("device class: %v", err)
+		}
+		u.Class, u.HasClass, u.DeviceClass = uint8(v), true, true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "Class_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("class: %v", err)
+		}
+		u.Class, u.HasClass = uint8(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "SubClass_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("subclass: %v", err)
+		}
+		u.SubClass, u.HasSubClass = uint8(v), true
+		return nil
+	}
+	if value, ok := cutPrefixFold(field, "Prot_"); ok {
+		v, err := parseHex(value, 2)
+		if err != nil {
+			return fmt.Errorf("protocol: %v", err)
+		}
+		u.Protocol, u.HasProtocol = uint8(v), true
+		return nil
+	}
+	return fmt.Errorf("unrecognized field \"%s\"", field)
+}
+
+// String returns the identifier formed by the fields present in u.
+func (u USB) String() string {
+	if u.Composite {
+		return `USB\COMPOSITE`
+	}
+
+	var fields []string
+	if u.HasVendor {
+		fields = append(fields, u.vid())
+	}
+	if u.HasProduct {
+		fields = append(fields, u.pid())
+	}
+	if u.HasRevision {
+		fields = append(fields, u.rev())
+	}
+	if u.HasInterface {
+		fields = append(fields, u.mi())
+	}
+	if u.HasClass {
+		fields = append(fields, u.class())
+	}
+	if u.HasSubClass {
+		fields = append(fields, u.subClass())
+	}
+	if u.HasProtocol {
+		fields = append(fields, u.prot())
+	}
+	return usbID(fields...)
+}
+
+// HardwareIDs returns the ranked list of hardware identifiers that Windows
+// generates for a USB device or interface described by u, from most to
+// least specific.
+//
+// The vendor and product fields must be present. Identifiers that depend on
+// missing fields are omitted.
+func (u USB) HardwareIDs() []Hardware {
+	if !u.HasVendor || !u.HasProduct {
+		return nil
+	}
+
+	var ids []Hardware
+	if u.HasInterface {
+		if u.HasRevision {
+			ids = append(ids, Hardware(usbID(u.vid(), u.pid(), u.rev(), u.mi())))
+		}
+		ids = append(ids, Hardware(usbID(u.vid(), u.pid(), u.mi())))
+	} else {
+		if u.HasRevision {
+			ids = append(ids, Hardware(usbID(u.vid(), u.pid(), u.rev())))
+		}
+		ids = append(ids, Hardware(usbID(u.vid(), u.pid())))
+	}
+	return ids
+}
+
+// CompatibleIDs returns the ranked list of compatible identifiers that
+// Windows generates for a USB device or interface described by u, from most
+// to least specific.
+//
+// The class fields must be present. Identifiers that depend on missing
+// fields are omitted. When u describes a composite device the
+// USB\COMPOSITE identifier is included last.
+func (u USB) CompatibleIDs() []Compatible {
+	var ids []Compatible
+	if u.HasClass {
+		if u.HasSubClass {
+			if u.HasProtocol {
+				ids = append(ids, Compatible(usbID(u.class(), u.subClass(), u.prot())))
+			}
+			ids = append(ids, Compatible(usbID(u.class(), u.subClass())))
+		}
+		ids = append(ids, Compatible(usbID(u.class())))
+	}
+	if u.Composite || u.DeviceClass {
+		ids = append(ids, Compatible(`USB\COMPOSITE`))
+	}
+	return ids
+}
+
+func (u USB) vid() string {
+	return fmt.Sprintf("VID_%04X", u.VendorID)
+}
+
+func (u USB) pid() string {
+	return fmt.Sprintf("PID_%04X", u.ProductID)
+}
+
+func (u USB) rev() string {
+	return fmt.Sprintf("REV_%04X", u.Revision)
+}
+
+func (u USB) mi() string {
+	return fmt.Sprintf("MI_%02X", u.Interface)
+}
+
+func (u USB) class() string {
+	if u.DeviceClass {
+		return fmt.Sprintf("DevClass_%02X", u.Class)
+	}
+	return fmt.Sprintf("Class_%02X", u.Class)
+}
+
+func (u USB) subClass() string {
+	return fmt.Sprintf("SubClass_%02X", u.SubClass)
+}
+
+func (u USB) prot() string {
+	return fmt.Sprintf("Prot_%02X", u.Protocol)
+}
+
+func usbID(fields ...string) string {
+	return `USB\` + strings.Join(fields, "&")
+}
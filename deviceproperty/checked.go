@@ -0,0 +1,459 @@
+package deviceproperty
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gentlemanautomaton/windevice/indirectstring"
+	"golang.org/x/sys/windows"
+)
+
+var (
+	// ErrTypeMismatch is returned when a value is accessed as a type that
+	// it does not have.
+	ErrTypeMismatch = errors.New("device property type mismatch")
+
+	// ErrInvalidLength is returned when the length of a value's data is not
+	// valid for its type.
+	ErrInvalidLength = errors.New("device property data has an invalid length")
+)
+
+// AsInt8 returns v as an int8. It returns an error if v does not have the
+// Int8 type or its data is not 1 byte long.
+func (v Value) AsInt8() (int8, error) {
+	if err := v.check(Int8); err != nil {
+		return 0, err
+	}
+	return v.Int8(), nil
+}
+
+// AsByte returns v as a byte. It returns an error if v does not have the
+// Byte type or its data is not 1 byte long.
+func (v Value) AsByte() (byte, error) {
+	if err := v.check(Byte); err != nil {
+		return 0, err
+	}
+	return v.Byte(), nil
+}
+
+// AsInt16 returns v as an int16. It returns an error if v does not have
+// the Int16 type or its data is not 2 bytes long.
+func (v Value) AsInt16() (int16, error) {
+	if err := v.check(Int16); err != nil {
+		return 0, err
+	}
+	return v.Int16(), nil
+}
+
+// AsUint16 returns v as a uint16. It returns an error if v does not have
+// the Uint16 type or its data is not 2 bytes long.
+func (v Value) AsUint16() (uint16, error) {
+	if err := v.check(Uint16); err != nil {
+		return 0, err
+	}
+	return v.Uint16(), nil
+}
+
+// AsInt32 returns v as an int32. It returns an error if v does not have
+// the Int32 type or its data is not 4 bytes long.
+func (v Value) AsInt32() (int32, error) {
+	if err := v.check(Int32); err != nil {
+		return 0, err
+	}
+	return v.Int32(), nil
+}
+
+// AsUint32 returns v as a uint32. It returns an error if v does not have
+// the Uint32 type or its data is not 4 bytes long.
+func (v Value) AsUint32() (uint32, error) {
+	if err := v.check(Uint32); err != nil {
+		return 0, err
+	}
+	return v.Uint32(), nil
+}
+
+// AsInt64 returns v as an int64. It returns an error if v does not have
+// the Int64 type or its data is not 8 bytes long.
+func (v Value) AsInt64() (int64, error) {
+	if err := v.check(Int64); err != nil {
+		return 0, err
+	}
+	return v.Int64(), nil
+}
+
+// AsUint64 returns v as a uint64. It returns an error if v does not have
+// the Uint64 type or its data is not 8 bytes long.
+func (v Value) AsUint64() (uint64, error) {
+	if err := v.check(Uint64); err != nil {
+		return 0, err
+	}
+	return v.Uint64(), nil
+}
+
+// AsFloat32 returns v as a float32. It returns an error if v does not have
+// the Float type or its data is not 4 bytes long.
+func (v Value) AsFloat32() (float32, error) {
+	if err := v.check(Float); err != nil {
+		return 0, err
+	}
+	return v.Float32(), nil
+}
+
+// AsFloat64 returns v as a float64. It returns an error if v does not have
+// the Double type or its data is not 8 bytes long.
+func (v Value) AsFloat64() (float64, error) {
+	if err := v.check(Double); err != nil {
+		return 0, err
+	}
+	return v.Float64(), nil
+}
+
+// AsDecimal returns v as an exact rational number. It returns an error if
+// v does not have the Decimal type or its data is not 16 bytes long.
+func (v Value) AsDecimal() (*big.Rat, error) {
+	if err := v.check(Decimal); err != nil {
+		return nil, err
+	}
+	return v.Decimal(), nil
+}
+
+// AsGUID returns v as a GUID. It returns an error if v does not have the
+// GUID type or its data is not 16 bytes long.
+func (v Value) AsGUID() (windows.GUID, error) {
+	if err := v.check(GUID); err != nil {
+		return windows.GUID{}, err
+	}
+	return v.GUID(), nil
+}
+
+// AsCurrency returns v as a currency amount. It returns an error if v
+// does not have the Currency type or its data is not 8 bytes long.
+func (v Value) AsCurrency() (CurrencyAmount, error) {
+	if err := v.check(Currency); err != nil {
+		return 0, err
+	}
+	return v.Currency(), nil
+}
+
+// AsDate returns v as a time. It returns an error if v does not have the
+// Date type or its data is not 8 bytes long. Use AsTime for FileTime
+// values.
+func (v Value) AsDate() (time.Time, error) {
+	if err := v.check(Date); err != nil {
+		return time.Time{}, err
+	}
+	return v.Date(), nil
+}
+
+// AsTime returns v as a time. It returns an error if v does not have the
+// FileTime type or its data is not 8 bytes long. Use AsDate for Date
+// values.
+func (v Value) AsTime() (time.Time, error) {
+	if err := v.check(FileTime); err != nil {
+		return time.Time{}, err
+	}
+	return v.Time(), nil
+}
+
+// AsBool returns v as a bool. It returns an error if v does not have the
+// Bool type or its data is not 1 byte long.
+func (v Value) AsBool() (bool, error) {
+	if err := v.check(Bool); err != nil {
+		return false, err
+	}
+	return v.Bool(), nil
+}
+
+// AsPropertyKey returns the property key held by v. It returns an error
+// if v does not have the DevicePropertyKey type or its data is not 20
+// bytes long.
+func (v Value) AsPropertyKey() (Key, error) {
+	if err := v.check(DevicePropertyKey); err != nil {
+		return Key{}, err
+	}
+	return v.PropertyKey(), nil
+}
+
+// AsPropertyType returns the property type held by v. It returns an
+// error if v does not have the DevicePropertyType type or its data is not
+// 4 bytes long.
+func (v Value) AsPropertyType() (Type, error) {
+	if err := v.check(DevicePropertyType); err != nil {
+		return 0, err
+	}
+	return v.PropertyType(), nil
+}
+
+// AsWin32Error returns v as a Win32 error code. It returns an error if v
+// does not have the Error type or its data is not 4 bytes long.
+func (v Value) AsWin32Error() (windows.Errno, error) {
+	if err := v.check(Error); err != nil {
+		return 0, err
+	}
+	return v.Win32Error(), nil
+}
+
+// AsStatus returns v as an NTSTATUS code. It returns an error if v does
+// not have the Status type or its data is not 4 bytes long.
+func (v Value) AsStatus() (windows.NTStatus, error) {
+	if err := v.check(Status); err != nil {
+		return 0, err
+	}
+	return v.Status(), nil
+}
+
+// AsString returns v as a string. It returns an error if v does not have
+// the String, SecurityDescriptorString or StringIndirect type. Indirect
+// strings are returned without being resolved.
+func (v Value) AsString() (string, error) {
+	if err := v.check(String, SecurityDescriptorString, StringIndirect); err != nil {
+		return "", err
+	}
+	return v.utf16String(), nil
+}
+
+// AsStringList returns v as a list of strings. It returns an error if v
+// does not have the StringList type or a list of security descriptor
+// strings.
+func (v Value) AsStringList() ([]string, error) {
+	if err := v.check(StringList, SecurityDescriptorString|List); err != nil {
+		return nil, err
+	}
+	if len(v.Bytes()) == 0 {
+		return nil, nil
+	}
+	return utf16BytesToSplitString(v.Bytes()), nil
+}
+
+// AsBinary returns the bytes of v. It returns an error if v does not have
+// the Binary type.
+func (v Value) AsBinary() ([]byte, error) {
+	if err := v.check(Binary); err != nil {
+		return nil, err
+	}
+	return v.Bytes(), nil
+}
+
+// AsIndirectString returns v as an indirect string. It returns an error if
+// v does not have the StringIndirect type or cannot be parsed.
+func (v Value) AsIndirectString() (indirectstring.Value, error) {
+	if err := v.check(StringIndirect); err != nil {
+		return indirectstring.Value{}, err
+	}
+	return v.IndirectString()
+}
+
+// AsSecurityDescriptor returns v as a self-relative security descriptor.
+// It returns an error if v does not have the SecurityDescriptor type or
+// does not hold a valid security descriptor.
+func (v Value) AsSecurityDescriptor() (*windows.SECURITY_DESCRIPTOR, error) {
+	if err := v.check(SecurityDescriptor); err != nil {
+		return nil, err
+	}
+	sd := v.SecurityDescriptor()
+	if sd == nil {
+		return nil, fmt.Errorf("%w: the value is not a valid security descriptor", ErrInvalidLength)
+	}
+	return sd, nil
+}
+
+// As returns v as a value of type T. It returns an error if T does not
+// correspond to the type of v or the data of v is not valid for its type.
+//
+// Scalar types such as uint32, windows.GUID and time.Time are supported,
+// along with slices of them for array types. A time.Time can be read from
+// both FileTime and Date values. A string can be read from String,
+// SecurityDescriptorString and StringIndirect values, and a []string from
+// StringList values.
+func As[T any](v Value) (T, error) {
+	var (
+		result T
+		value  any
+		err    error
+	)
+	switch any(result).(type) {
+	case int8:
+		value, err = v.AsInt8()
+	case byte:
+		value, err = v.AsByte()
+	case int16:
+		value, err = v.AsInt16()
+	case uint16:
+		value, err = v.AsUint16()
+	case int32:
+		value, err = v.AsInt32()
+	case uint32:
+		value, err = v.AsUint32()
+	case int64:
+		value, err = v.AsInt64()
+	case uint64:
+		value, err = v.AsUint64()
+	case float32:
+		value, err = v.AsFloat32()
+	case float64:
+		value, err = v.AsFloat64()
+	case *big.Rat:
+		value, err = v.AsDecimal()
+	case windows.GUID:
+		value, err = v.AsGUID()
+	case CurrencyAmount:
+		value, err = v.AsCurrency()
+	case time.Time:
+		if err = v.check(FileTime, Date); err == nil {
+			if v.t == Date {
+				value = v.Date()
+			} else {
+				value = v.Time()
+			}
+		}
+	case bool:
+		value, err = v.AsBool()
+	case Key:
+		value, err = v.AsPropertyKey()
+	case Type:
+		value, err = v.AsPropertyType()
+	case windows.Errno:
+		value, err = v.AsWin32Error()
+	case windows.NTStatus:
+		value, err = v.AsStatus()
+	case string:
+		value, err = v.AsString()
+	case []string:
+		value, err = v.AsStringList()
+	case []byte:
+		value, err = v.AsBinary()
+	case indirectstring.Value:
+		value, err = v.AsIndirectString()
+	case *windows.SECURITY_DESCRIPTOR:
+		value, err = v.AsSecurityDescriptor()
+	case []int8:
+		if err = v.check(Int8 | Array); err == nil {
+			value = v.Int8List()
+		}
+	case []int16:
+		if err = v.check(Int16 | Array); err == nil {
+			value = v.Int16List()
+		}
+	case []uint16:
+		if err = v.check(Uint16 | Array); err == nil {
+			value = v.Uint16List()
+		}
+	case []int32:
+		if err = v.check(Int32 | Array); err == nil {
+			value = v.Int32List()
+		}
+	case []uint32:
+		if err = v.check(Uint32 | Array); err == nil {
+			value = v.Uint32List()
+		}
+	case []int64:
+		if err = v.check(Int64 | Array); err == nil {
+			value = v.Int64List()
+		}
+	case []uint64:
+		if err = v.check(Uint64 | Array); err == nil {
+			value = v.Uint64List()
+		}
+	case []float32:
+		if err = v.check(Float | Array); err == nil {
+			value = v.Float32List()
+		}
+	case []float64:
+		if err = v.check(Double | Array); err == nil {
+			value = v.Float64List()
+		}
+	case []*big.Rat:
+		if err = v.check(Decimal | Array); err == nil {
+			value = v.DecimalList()
+		}
+	case []windows.GUID:
+		if err = v.check(GUID | Array); err == nil {
+			value = v.GUIDList()
+		}
+	case []CurrencyAmount:
+		if err = v.check(Currency | Array); err == nil {
+			value = v.CurrencyList()
+		}
+	case []bool:
+		if err = v.check(Bool | Array); err == nil {
+			value = v.BoolList()
+		}
+	case []Key:
+		if err = v.check(DevicePropertyKey | Array); err == nil {
+			value = v.PropertyKeyList()
+		}
+	case []Type:
+		if err = v.check(DevicePropertyType | Array); err == nil {
+			value = v.PropertyTypeList()
+		}
+	case []windows.Errno:
+		if err = v.check(Error | Array); err == nil {
+			value = v.Win32ErrorList()
+		}
+	case []windows.NTStatus:
+		if err = v.check(Status | Array); err == nil {
+			value = v.StatusList()
+		}
+	case []time.Time:
+		if err = v.check(FileTime|Array, Date|Array); err == nil {
+			if v.t.Base() == Date {
+				value = v.DateList()
+			} else {
+				value = v.TimeList()
+			}
+		}
+	default:
+		return result, fmt.Errorf("%T is not a supported device property value type", result)
+	}
+	if err != nil {
+		return result, err
+	}
+	return value.(T), nil
+}
+
+// check returns an error if v does not have one of the given types, or if
+// the length of its data is not valid for its type.
+func (v Value) check(types ...Type) error {
+	matched := false
+	for _, t := range types {
+		if v.t == t {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		names := make([]string, len(types))
+		for i, t := range types {
+			names[i] = t.String()
+		}
+		return fmt.Errorf("%w: the value has type %s instead of %s", ErrTypeMismatch, v.t, strings.Join(names, " or "))
+	}
+
+	length := len(v.Bytes())
+	switch base := v.t.Base(); {
+	case base == String || base == SecurityDescriptorString || base == StringIndirect:
+		if length%2 != 0 {
+			return fmt.Errorf("%w: %s data has an odd length of %d bytes", ErrInvalidLength, v.t, length)
+		}
+	case v.t.Modifier() == Array:
+		if size := base.DataLength(); size <= 0 || length%size != 0 {
+			return fmt.Errorf("%w: %s data of %d bytes is not a whole number of elements", ErrInvalidLength, v.t, length)
+		}
+	default:
+		if size := v.t.DataLength(); size >= 0 && length != size {
+			return fmt.Errorf("%w: %s data is %d bytes instead of %d", ErrInvalidLength, v.t, length, size)
+		}
+	}
+	return nil
+}
+
+// utf16String returns the data of v as a string.
+func (v Value) utf16String() string {
+	if data := v.Bytes(); len(data) > 0 {
+		return utf16BytesToString(data)
+	}
+	return ""
+}
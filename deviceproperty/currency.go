@@ -0,0 +1,43 @@
+package deviceproperty
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// CurrencyAmount is a fixed-point currency amount with four decimal
+// places. It has the same representation as the CY type, which stores the
+// amount multiplied by 10,000.
+//
+// https://docs.microsoft.com/en-us/windows/win32/api/wtypes/ns-wtypes-cy-r1
+type CurrencyAmount int64
+
+// currencyScale is the number of CurrencyAmount units in one whole unit.
+const currencyScale = 10000
+
+// Rat returns the amount as a rational number.
+func (c CurrencyAmount) Rat() *big.Rat {
+	return big.NewRat(int64(c), currencyScale)
+}
+
+// Float64 returns the amount as a floating point number, which may lose
+// precision.
+func (c CurrencyAmount) Float64() float64 {
+	return float64(c) / currencyScale
+}
+
+// String returns the amount as a decimal number with four decimal places,
+// such as "-12.3400".
+func (c CurrencyAmount) String() string {
+	sign := ""
+	u := uint64(c)
+	if c < 0 {
+		sign = "-"
+		u = -u
+	}
+	frac := strconv.FormatUint(u%currencyScale, 10)
+	for len(frac) < 4 {
+		frac = "0" + frac
+	}
+	return sign + strconv.FormatUint(u/currencyScale, 10) + "." + frac
+}
@@ -0,0 +1,98 @@
+package deviceproperty
+
+import (
+	"time"
+
+	"github.com/gentlemanautomaton/windevice/devpropdata"
+	"github.com/gentlemanautomaton/winguid"
+	"golang.org/x/sys/windows"
+)
+
+// NewInt8 returns an Int8 value.
+func NewInt8(i int8) Value {
+	return NewValue(Int8, []byte{byte(i)})
+}
+
+// NewByte returns a Byte value.
+func NewByte(b byte) Value {
+	return NewValue(Byte, []byte{b})
+}
+
+// NewInt16 returns an Int16 value.
+func NewInt16(i int16) Value {
+	return NewValue(Int16, devpropdata.Uint16(uint16(i)))
+}
+
+// NewUint16 returns a Uint16 value.
+func NewUint16(i uint16) Value {
+	return NewValue(Uint16, devpropdata.Uint16(i))
+}
+
+// NewInt32 returns an Int32 value.
+func NewInt32(i int32) Value {
+	return NewValue(Int32, devpropdata.Uint32(uint32(i)))
+}
+
+// NewUint32 returns a Uint32 value.
+func NewUint32(i uint32) Value {
+	return NewValue(Uint32, devpropdata.Uint32(i))
+}
+
+// NewInt64 returns an Int64 value.
+func NewInt64(i int64) Value {
+	return NewValue(Int64, devpropdata.Uint64(uint64(i)))
+}
+
+// NewUint64 returns a Uint64 value.
+func NewUint64(i uint64) Value {
+	return NewValue(Uint64, devpropdata.Uint64(i))
+}
+
+// NewBool returns a Bool value.
+func NewBool(b bool) Value {
+	return NewValue(Bool, devpropdata.Bool(b))
+}
+
+// NewGUID returns a GUID value.
+func NewGUID(guid windows.GUID) Value {
+	var data [16]byte
+	winguid.NativeEndian.PutGUID(data[:], guid)
+	return NewValue(GUID, data[:])
+}
+
+// NewFileTime returns a FileTime value for t. It returns an error if t is
+// outside of the FILETIME range, which starts on January 1, 1601 UTC.
+// Precision beyond 100 nanoseconds is truncated.
+func NewFileTime(t time.Time) (Value, error) {
+	data, err := devpropdata.FileTime(t)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewValue(FileTime, data), nil
+}
+
+// NewBinary returns a Binary value that holds a copy of data.
+func NewBinary(data []byte) Value {
+	return NewValue(Binary, append([]byte(nil), data...))
+}
+
+// NewString returns a String value. It returns an error if s contains a
+// null character.
+func NewString(s string) (Value, error) {
+	data, err := devpropdata.String(s)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewValue(String, data), nil
+}
+
+// NewStringList returns a StringList value. It returns an error if any of
+// the strings are empty or contain a null character, as those cannot be
+// represented in a list.
+func NewStringList(list []string) (Value, error) {
+	data, err := devpropdata.StringList(list)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewValue(StringList, data), nil
+}
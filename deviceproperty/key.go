@@ -1,7 +1,10 @@
 package deviceproperty
 
 import (
+	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/gentlemanautomaton/winguid"
 	"golang.org/x/sys/windows"
@@ -25,3 +28,54 @@ func (k Key) String() string {
 func (k Key) Name() string {
 	return KnownKeys[k]
 }
+
+// MarshalText returns the key in the form "{category} propertyID", such as
+// "{A45C254E-DF1C-4EFD-8020-67D146A850E0} 14".
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(winguid.String(k.Category) + " " + strconv.FormatUint(uint64(k.PropertyID), 10)), nil
+}
+
+// UnmarshalText parses a key in the form "{category} propertyID". The name
+// of a known key, such as "System.Devices.ModelId", is also accepted.
+func (k *Key) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	category, id, found := strings.Cut(s, " ")
+	if !found {
+		for key, name := range KnownKeys {
+			if name == s {
+				*k = key
+				return nil
+			}
+		}
+		return fmt.Errorf("\"%s\" is not a device property key", s)
+	}
+	guid, ok := winguid.TryNew(category)
+	if !ok {
+		return fmt.Errorf("device property key \"%s\" has an invalid category", s)
+	}
+	pid, err := strconv.ParseUint(strings.TrimSpace(id), 10, 32)
+	if err != nil {
+		return fmt.Errorf("device property key \"%s\" has an invalid property ID", s)
+	}
+	k.Category, k.PropertyID = guid, uint32(pid)
+	return nil
+}
+
+// MarshalJSON returns the key as a JSON string in the form produced by
+// MarshalText.
+func (k Key) MarshalJSON() ([]byte, error) {
+	text, err := k.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses a key from a JSON string.
+func (k *Key) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return k.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,524 @@
+package deviceproperty
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/gentlemanautomaton/windevice/devpropdata"
+	"github.com/gentlemanautomaton/winguid"
+)
+
+// valueJSON is the JSON representation of a value.
+//
+// Values are normally stored in the value field in a form that suits their
+// type. Values that cannot be represented that way without loss, such as
+// values with malformed data or types that are not known, are stored as
+// raw bytes in the data field instead.
+type valueJSON struct {
+	Type  Type            `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Data  []byte          `json:"data,omitempty"`
+}
+
+// propertyJSON is the JSON representation of a property.
+type propertyJSON struct {
+	Key   Key             `json:"key"`
+	Type  Type            `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Data  []byte          `json:"data,omitempty"`
+}
+
+// MarshalJSON returns a JSON representation of the property that
+// includes its key and type, such as
+// {"key":"{540B947E-8B40-45BC-A8A2-6A0B894CBDA2} 4","type":"String","value":"Contoso Widget"}.
+func (p Property) MarshalJSON() ([]byte, error) {
+	value, data, err := p.Value.encode()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(propertyJSON{Key: p.Key, Type: p.Value.t, Value: value, Data: data})
+}
+
+// UnmarshalJSON parses a JSON representation of the property.
+func (p *Property) UnmarshalJSON(b []byte) error {
+	var j propertyJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	value, err := decode(j.Type, j.Value, j.Data)
+	if err != nil {
+		return err
+	}
+	p.Key, p.Value = j.Key, value
+	return nil
+}
+
+// MarshalText returns a text representation of the property in the form
+// "key type value", such as
+// `{540B947E-8B40-45BC-A8A2-6A0B894CBDA2} 4 String "Contoso Widget"`.
+func (p Property) MarshalText() ([]byte, error) {
+	key, err := p.Key.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.Value.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return append(append(key, ' '), value...), nil
+}
+
+// UnmarshalText parses a text representation of the property.
+func (p *Property) UnmarshalText(text []byte) error {
+	s := string(text)
+	var key, rest string
+	if strings.HasPrefix(s, "{") {
+		guid, after, _ := strings.Cut(s, " ")
+		id, after, _ := strings.Cut(after, " ")
+		key, rest = guid+" "+id, after
+	} else {
+		key, rest, _ = strings.Cut(s, " ")
+	}
+	if err := p.Key.UnmarshalText([]byte(key)); err != nil {
+		return err
+	}
+	return p.Value.UnmarshalText([]byte(rest))
+}
+
+// MarshalJSON returns a JSON representation of the value that includes
+// its type, such as {"type":"Uint32","value":5} or
+// {"type":"Binary","value":"AQID"}.
+func (v Value) MarshalJSON() ([]byte, error) {
+	value, data, err := v.encode()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(valueJSON{Type: v.t, Value: value, Data: data})
+}
+
+// UnmarshalJSON parses a JSON representation of the value.
+func (v *Value) UnmarshalJSON(b []byte) error {
+	var j valueJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	value, err := decode(j.Type, j.Value, j.Data)
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// MarshalText returns a text representation of the value in the form
+// "type value", such as `Uint32 5` or `String "Contoso Widget"`. The value
+// is written in the same form as the value field of the JSON
+// representation. Values that are stored as raw bytes are written as
+// "type data:base64".
+func (v Value) MarshalText() ([]byte, error) {
+	value, data, err := v.encode()
+	if err != nil {
+		return nil, err
+	}
+	text, err := v.t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case data != nil:
+		text = append(text, " data:"...)
+		text = append(text, base64.StdEncoding.EncodeToString(data)...)
+	case value != nil:
+		text = append(text, ' ')
+		text = append(text, value...)
+	}
+	return text, nil
+}
+
+// UnmarshalText parses a text representation of the value.
+func (v *Value) UnmarshalText(text []byte) error {
+	typeText, rest, _ := strings.Cut(string(text), " ")
+	var t Type
+	if err := t.UnmarshalText([]byte(typeText)); err != nil {
+		return err
+	}
+	var (
+		value json.RawMessage
+		data  []byte
+	)
+	if encoded := strings.TrimPrefix(rest, "data:"); encoded != rest {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return fmt.Errorf("invalid %s data: %v", t, err)
+		}
+	} else if rest != "" {
+		value = json.RawMessage(rest)
+	}
+	decoded, err := decode(t, value, data)
+	if err != nil {
+		return err
+	}
+	*v = decoded
+	return nil
+}
+
+// encode returns the JSON encoding of the data held by v. If v cannot be
+// encoded without loss it returns its raw data instead.
+func (v Value) encode() (value json.RawMessage, data []byte, err error) {
+	raw := v.Bytes()
+	if v.t == Empty || v.t == Null {
+		if len(raw) == 0 {
+			return nil, nil, nil
+		}
+		return nil, raw, nil
+	}
+	if v.check(v.t) == nil {
+		if element, ok := encodeData(v.t, raw); ok {
+			if value, err = json.Marshal(element); err != nil {
+				return nil, nil, err
+			}
+			// Only use the encoded value if it reproduces the data exactly
+			if decoded, err := decodeData(v.t, value); err == nil && bytes.Equal(decoded, raw) {
+				return value, nil, nil
+			}
+		}
+	}
+	if raw == nil {
+		raw = []byte{}
+	}
+	return nil, raw, nil
+}
+
+// decode returns a value of type t from its JSON encoding or raw data.
+func decode(t Type, value json.RawMessage, data []byte) (Value, error) {
+	if data != nil || len(value) == 0 || bytes.Equal(value, []byte("null")) {
+		return NewValue(t, data), nil
+	}
+	decoded, err := decodeData(t, value)
+	if err != nil {
+		return Value{}, err
+	}
+	return NewValue(t, decoded), nil
+}
+
+// encodeData returns a value that can be marshaled to JSON for data of
+// type t. It returns false if the type is not supported.
+func encodeData(t Type, data []byte) (interface{}, bool) {
+	base := t.Base()
+	switch t.Modifier() {
+	case 0:
+		switch base {
+		case String, SecurityDescriptorString, StringIndirect:
+			return decodeUTF16(data), true
+		case SecurityDescriptor:
+			return data, true
+		}
+		return encodeElement(base, data)
+	case Array:
+		if t == Binary {
+			return data, true
+		}
+		size := base.DataLength()
+		if size <= 0 {
+			return nil, false
+		}
+		elements := make([]interface{}, 0, len(data)/size)
+		for offset := 0; offset+size <= len(data); offset += size {
+			element, ok := encodeElement(base, data[offset:offset+size])
+			if !ok {
+				return nil, false
+			}
+			elements = append(elements, element)
+		}
+		return elements, true
+	case List:
+		switch base {
+		case String, SecurityDescriptorString:
+			list := strings.Split(decodeUTF16(data), "\x00")
+			if len(list) == 1 && list[0] == "" {
+				return []string{}, true
+			}
+			return list, true
+		}
+	}
+	return nil, false
+}
+
+// decodeData returns the data of type t that is encoded in value.
+func decodeData(t Type, value json.RawMessage) ([]byte, error) {
+	base := t.Base()
+	switch t.Modifier() {
+	case 0:
+		switch base {
+		case String, SecurityDescriptorString, StringIndirect:
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return nil, err
+			}
+			return devpropdata.String(s)
+		case SecurityDescriptor:
+			var data []byte
+			err := json.Unmarshal(value, &data)
+			return data, err
+		}
+		if base.DataLength() < 0 {
+			break
+		}
+		return decodeElement(base, value)
+	case Array:
+		if t == Binary {
+			var data []byte
+			err := json.Unmarshal(value, &data)
+			return data, err
+		}
+		if base.DataLength() <= 0 {
+			break
+		}
+		var elements []json.RawMessage
+		if err := json.Unmarshal(value, &elements); err != nil {
+			return nil, err
+		}
+		data := []byte{}
+		for _, element := range elements {
+			b, err := decodeElement(base, element)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, b...)
+		}
+		return data, nil
+	case List:
+		switch base {
+		case String, SecurityDescriptorString:
+			var list []string
+			if err := json.Unmarshal(value, &list); err != nil {
+				return nil, err
+			}
+			return devpropdata.StringList(list)
+		}
+	}
+	return nil, fmt.Errorf("values of type %s must be stored as raw data", t)
+}
+
+// encodeElement returns a value that can be marshaled to JSON for a single
+// element of a fixed-length base type.
+func encodeElement(base Type, data []byte) (interface{}, bool) {
+	switch base {
+	case Int8:
+		return int8(data[0]), true
+	case Byte:
+		return data[0], true
+	case Int16:
+		return int16(binary.LittleEndian.Uint16(data)), true
+	case Uint16:
+		return binary.LittleEndian.Uint16(data), true
+	case Int32:
+		return int32(binary.LittleEndian.Uint32(data)), true
+	case Uint32, Error, Status:
+		return binary.LittleEndian.Uint32(data), true
+	case Int64:
+		return int64(binary.LittleEndian.Uint64(data)), true
+	case Uint64:
+		return binary.LittleEndian.Uint64(data), true
+	case Float:
+		f := math.Float32frombits(binary.LittleEndian.Uint32(data))
+		return f, isFinite(float64(f))
+	case Double, Date:
+		f := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		return f, isFinite(f)
+	case Decimal:
+		return formatDecimal(data), true
+	case GUID:
+		return winguid.String(winguid.NativeEndian.GUID(data)), true
+	case Currency:
+		return CurrencyAmount(binary.LittleEndian.Uint64(data)).String(), true
+	case FileTime:
+		return devpropdata.FromFileTime(binary.LittleEndian.Uint64(data)).Format(time.RFC3339Nano), true
+	case Bool:
+		return data[0] != devpropdata.False, true
+	case DevicePropertyKey:
+		key := Key{
+			Category:   winguid.NativeEndian.GUID(data[:16]),
+			PropertyID: binary.LittleEndian.Uint32(data[16:20]),
+		}
+		return key, true
+	case DevicePropertyType:
+		return Type(binary.LittleEndian.Uint32(data)), true
+	}
+	return nil, false
+}
+
+// decodeElement returns the data for a single element of a fixed-length
+// base type that is encoded in value.
+func decodeElement(base Type, value json.RawMessage) ([]byte, error) {
+	data := make([]byte, base.DataLength())
+	var err error
+	switch base {
+	case Int8:
+		var i int8
+		err = json.Unmarshal(value, &i)
+		data[0] = byte(i)
+	case Byte:
+		err = json.Unmarshal(value, &data[0])
+	case Int16:
+		var i int16
+		err = json.Unmarshal(value, &i)
+		binary.LittleEndian.PutUint16(data, uint16(i))
+	case Uint16:
+		var i uint16
+		err = json.Unmarshal(value, &i)
+		binary.LittleEndian.PutUint16(data, i)
+	case Int32:
+		var i int32
+		err = json.Unmarshal(value, &i)
+		binary.LittleEndian.PutUint32(data, uint32(i))
+	case Uint32, Error, Status:
+		var i uint32
+		err = json.Unmarshal(value, &i)
+		binary.LittleEndian.PutUint32(data, i)
+	case Int64:
+		var i int64
+		err = json.Unmarshal(value, &i)
+		binary.LittleEndian.PutUint64(data, uint64(i))
+	case Uint64:
+		var i uint64
+		err = json.Unmarshal(value, &i)
+		binary.LittleEndian.PutUint64(data, i)
+	case Float:
+		var f float32
+		err = json.Unmarshal(value, &f)
+		binary.LittleEndian.PutUint32(data, math.Float32bits(f))
+	case Double, Date:
+		var f float64
+		err = json.Unmarshal(value, &f)
+		binary.LittleEndian.PutUint64(data, math.Float64bits(f))
+	case Decimal:
+		var s string
+		if err = json.Unmarshal(value, &s); err == nil {
+			err = parseDecimal(s, data)
+		}
+	case GUID:
+		var s string
+		if err = json.Unmarshal(value, &s); err == nil {
+			guid, ok := winguid.TryNew(s)
+			if !ok {
+				return nil, fmt.Errorf("\"%s\" is not a valid GUID", s)
+			}
+			winguid.NativeEndian.PutGUID(data, guid)
+		}
+	case Currency:
+		var s string
+		if err = json.Unmarshal(value, &s); err == nil {
+			var c CurrencyAmount
+			if c, err = parseCurrency(s); err == nil {
+				binary.LittleEndian.PutUint64(data, uint64(c))
+			}
+		}
+	case FileTime:
+		var t time.Time
+		if err = json.Unmarshal(value, &t); err == nil {
+			var ft uint64
+			if ft, err = timeToFiletime(t); err == nil {
+				binary.LittleEndian.PutUint64(data, ft)
+			}
+		}
+	case Bool:
+		var b bool
+		err = json.Unmarshal(value, &b)
+		if b {
+			data[0] = devpropdata.True
+		}
+	case DevicePropertyKey:
+		var key Key
+		if err = json.Unmarshal(value, &key); err == nil {
+			winguid.NativeEndian.PutGUID(data[:16], key.Category)
+			binary.LittleEndian.PutUint32(data[16:20], key.PropertyID)
+		}
+	case DevicePropertyType:
+		var t Type
+		err = json.Unmarshal(value, &t)
+		binary.LittleEndian.PutUint32(data, uint32(t))
+	default:
+		return nil, fmt.Errorf("values of type %s must be stored as raw data", base)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %v", base, err)
+	}
+	return data, nil
+}
+
+// decodeUTF16 returns the string held by UTF-16LE data, without its final
+// null terminator. Null characters within the data are preserved.
+func decodeUTF16(data []byte) string {
+	chars := make([]uint16, len(data)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(data[i*2:])
+	}
+	for len(chars) > 0 && chars[len(chars)-1] == 0 {
+		chars = chars[:len(chars)-1]
+	}
+	return string(utf16.Decode(chars))
+}
+
+// parseDecimal parses the decimal representation of a DECIMAL value into
+// data. The number of digits after the decimal point determines the scale.
+func parseDecimal(s string, data []byte) error {
+	digits := strings.TrimPrefix(s, "-")
+	negative := digits != s
+	whole, frac, _ := strings.Cut(digits, ".")
+	if len(frac) > 28 {
+		return fmt.Errorf("decimal \"%s\" has more than 28 digits after the decimal point", s)
+	}
+	n, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok || n.Sign() < 0 || strings.ContainsAny(whole+frac, "+-_") {
+		return fmt.Errorf("\"%s\" is not a decimal number", s)
+	}
+	if n.BitLen() > 96 {
+		return fmt.Errorf("decimal \"%s\" is too large", s)
+	}
+
+	data[2] = byte(len(frac))
+	if negative {
+		data[3] = 0x80
+	}
+	lo := new(big.Int).And(n, new(big.Int).SetUint64(math.MaxUint64))
+	hi := new(big.Int).Rsh(n, 64)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(hi.Uint64()))
+	binary.LittleEndian.PutUint64(data[8:16], lo.Uint64())
+	return nil
+}
+
+// parseCurrency parses a decimal representation of a currency amount.
+func parseCurrency(s string) (CurrencyAmount, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("\"%s\" is not a decimal number", s)
+	}
+	r.Mul(r, big.NewRat(currencyScale, 1))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("\"%s\" is not a valid currency amount", s)
+	}
+	return CurrencyAmount(r.Num().Int64()), nil
+}
+
+// timeToFiletime converts a time to a FILETIME value. It returns an error
+// if the time cannot be represented exactly.
+func timeToFiletime(t time.Time) (uint64, error) {
+	if t.Nanosecond()%100 != 0 {
+		return 0, errors.New("FILETIME values have a precision of 100 nanoseconds")
+	}
+	return devpropdata.ToFileTime(t)
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
@@ -1,5 +1,12 @@
 package deviceproperty
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // Device property type masks.
 const (
 	BaseTypeMask     = 0x00000FFF // DEVPROP_MASK_TYPE
@@ -62,6 +69,153 @@ func (t Type) Modifier() Type {
 }
 
 // DataLength returns the length of data required to store a value of type t.
+// It returns -1 for types with a variable length, which include strings,
+// security descriptors and all array and list types.
 func (t Type) DataLength() int {
-	return -1
+	if t.Modifier() != 0 {
+		return -1
+	}
+	switch t {
+	case Empty, Null:
+		return 0
+	case Int8, Byte, Bool:
+		return 1
+	case Int16, Uint16:
+		return 2
+	case Int32, Uint32, Float, DevicePropertyType, Error, Status:
+		return 4
+	case Int64, Uint64, Double, Currency, Date, FileTime:
+		return 8
+	case Decimal, GUID:
+		return 16
+	case DevicePropertyKey:
+		return 20
+	default:
+		return -1
+	}
+}
+
+// String returns a string representation of the type.
+func (t Type) String() string {
+	switch t {
+	case Binary:
+		return "Binary"
+	case StringList:
+		return "StringList"
+	}
+	var name string
+	switch t.Base() {
+	case Empty:
+		name = "Empty"
+	case Null:
+		name = "Null"
+	case Int8:
+		name = "Int8"
+	case Byte:
+		name = "Byte"
+	case Int16:
+		name = "Int16"
+	case Uint16:
+		name = "Uint16"
+	case Int32:
+		name = "Int32"
+	case Uint32:
+		name = "Uint32"
+	case Int64:
+		name = "Int64"
+	case Uint64:
+		name = "Uint64"
+	case Float:
+		name = "Float"
+	case Double:
+		name = "Double"
+	case Decimal:
+		name = "Decimal"
+	case GUID:
+		name = "GUID"
+	case Currency:
+		name = "Currency"
+	case Date:
+		name = "Date"
+	case FileTime:
+		name = "FileTime"
+	case Bool:
+		name = "Bool"
+	case String:
+		name = "String"
+	case SecurityDescriptor:
+		name = "SecurityDescriptor"
+	case SecurityDescriptorString:
+		name = "SecurityDescriptorString"
+	case DevicePropertyKey:
+		name = "DevicePropertyKey"
+	case DevicePropertyType:
+		name = "DevicePropertyType"
+	case Error:
+		name = "Error"
+	case Status:
+		name = "Status"
+	case StringIndirect:
+		name = "StringIndirect"
+	default:
+		return fmt.Sprintf("Unknown Type %d", uint32(t))
+	}
+	switch t.Modifier() {
+	case 0:
+		return name
+	case Array:
+		return name + "|Array"
+	case List:
+		return name + "|List"
+	default:
+		return fmt.Sprintf("Unknown Type %d", uint32(t))
+	}
+}
+
+// MarshalText returns the name of the type, such as "String" or
+// "Uint32|Array". Types that are not known are written as a hexadecimal
+// number.
+func (t Type) MarshalText() ([]byte, error) {
+	s := t.String()
+	if strings.HasPrefix(s, "Unknown") {
+		s = fmt.Sprintf("0x%08X", uint32(t))
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText parses the name of a type or a type number.
+func (t *Type) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if v, err := strconv.ParseUint(s, 0, 32); err == nil {
+		*t = Type(v)
+		return nil
+	}
+	for base := Empty; base <= StringIndirect; base++ {
+		for _, modifier := range []Type{0, Array, List} {
+			if candidate := base | modifier; candidate.String() == s {
+				*t = candidate
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("\"%s\" is not a device property type", s)
+}
+
+// MarshalJSON returns the type as a JSON string in the form produced by
+// MarshalText.
+func (t Type) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses a type from a JSON string.
+func (t *Type) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
 }
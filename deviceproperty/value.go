@@ -2,11 +2,17 @@ package deviceproperty
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
+	"unsafe"
 
+	"github.com/gentlemanautomaton/windevice/devpropdata"
+	"github.com/gentlemanautomaton/windevice/indirectstring"
 	"github.com/gentlemanautomaton/winguid"
 	"golang.org/x/sys/windows"
 )
@@ -129,6 +135,17 @@ func (v Value) String() string {
 			return fmt.Sprintf("%v", v.Float64List())
 		}
 	case Decimal:
+		switch v.t.Modifier() {
+		case 0:
+			return formatDecimal(v.array[:16])
+		case Array:
+			data := v.Bytes()
+			s := make([]string, 0, len(data)/16)
+			for i := 0; i+16 <= len(data); i += 16 {
+				s = append(s, formatDecimal(data[i:i+16]))
+			}
+			return fmt.Sprintf("%v", s)
+		}
 	case GUID:
 		switch v.t.Modifier() {
 		case 0:
@@ -142,7 +159,19 @@ func (v Value) String() string {
 			return fmt.Sprintf("%v", s)
 		}
 	case Currency:
+		switch v.t.Modifier() {
+		case 0:
+			return v.Currency().String()
+		case Array:
+			return fmt.Sprintf("%v", v.CurrencyList())
+		}
 	case Date:
+		switch v.t.Modifier() {
+		case 0:
+			return fmt.Sprintf("%v", v.Date())
+		case Array:
+			return fmt.Sprintf("%v", v.DateList())
+		}
 	case FileTime:
 		switch v.t.Modifier() {
 		case 0:
@@ -165,12 +194,64 @@ func (v Value) String() string {
 			return fmt.Sprintf("%v", utf16BytesToSplitString(v.Bytes()))
 		}
 	case SecurityDescriptor:
+		if v.t.Modifier() == 0 {
+			if sd := v.SecurityDescriptor(); sd != nil {
+				return sd.String()
+			}
+		}
 	case SecurityDescriptorString:
+		switch v.t.Modifier() {
+		case 0:
+			if data := v.Bytes(); len(data) > 0 {
+				return utf16BytesToString(data)
+			}
+		case List:
+			if data := v.Bytes(); len(data) > 0 {
+				return fmt.Sprintf("%v", utf16BytesToSplitString(data))
+			}
+		}
 	case DevicePropertyKey:
+		switch v.t.Modifier() {
+		case 0:
+			return v.PropertyKey().String()
+		case Array:
+			return fmt.Sprintf("%v", v.PropertyKeyList())
+		}
 	case DevicePropertyType:
+		switch v.t.Modifier() {
+		case 0:
+			return v.PropertyType().String()
+		case Array:
+			return fmt.Sprintf("%v", v.PropertyTypeList())
+		}
 	case Error:
+		switch v.t.Modifier() {
+		case 0:
+			return formatCode(uint32(v.Win32Error()), v.Win32Error())
+		case Array:
+			list := v.Win32ErrorList()
+			s := make([]string, 0, len(list))
+			for _, e := range list {
+				s = append(s, formatCode(uint32(e), e))
+			}
+			return fmt.Sprintf("%v", s)
+		}
 	case Status:
+		switch v.t.Modifier() {
+		case 0:
+			return formatCode(uint32(v.Status()), v.Status())
+		case Array:
+			list := v.StatusList()
+			s := make([]string, 0, len(list))
+			for _, e := range list {
+				s = append(s, formatCode(uint32(e), e))
+			}
+			return fmt.Sprintf("%v", s)
+		}
 	case StringIndirect:
+		if data := v.Bytes(); v.t.Modifier() == 0 && len(data) > 0 {
+			return indirectstring.Fallback(utf16BytesToString(data))
+		}
 	}
 	return ""
 }
@@ -232,11 +313,7 @@ func (v Value) GUID() windows.GUID {
 
 // Time interprets v as time.Time.
 func (v Value) Time() time.Time {
-	filetime := windows.Filetime{
-		LowDateTime:  binary.LittleEndian.Uint32(v.array[0:4]),
-		HighDateTime: binary.LittleEndian.Uint32(v.array[4:8]),
-	}
-	return time.Unix(0, filetime.Nanoseconds())
+	return devpropdata.FromFileTime(binary.LittleEndian.Uint64(v.array[0:8])).Local()
 }
 
 // Bool interprets v as bool.
@@ -244,6 +321,71 @@ func (v Value) Bool() bool {
 	return v.array[0] != 0
 }
 
+// IndirectString interprets v as an indirect string. The string can be
+// resolved with an inf.Resolver.
+func (v Value) IndirectString() (indirectstring.Value, error) {
+	if len(v.Bytes()) == 0 {
+		return indirectstring.Value{}, errors.New("indirect string value is empty")
+	}
+	return indirectstring.Parse(utf16BytesToString(v.Bytes()))
+}
+
+// Decimal interprets v as a DECIMAL value.
+func (v Value) Decimal() *big.Rat {
+	return decodeDecimal(v.array[:16])
+}
+
+// Currency interprets v as a CY value.
+func (v Value) Currency() CurrencyAmount {
+	return CurrencyAmount(v.Int64())
+}
+
+// Date interprets v as an OLE automation date. The returned time is in
+// UTC, although OLE automation dates do not record a time zone.
+func (v Value) Date() time.Time {
+	return decodeDate(v.Float64())
+}
+
+// SecurityDescriptor interprets v as a self-relative security descriptor.
+// It returns nil if v does not hold a valid security descriptor.
+func (v Value) SecurityDescriptor() *windows.SECURITY_DESCRIPTOR {
+	data := v.Bytes()
+	if len(data) < securityDescriptorMinLength {
+		return nil
+	}
+	// Copy the data into a buffer with suitable alignment
+	buffer := make([]uint64, (len(data)+7)/8)
+	copy((*[1 << 30]byte)(unsafe.Pointer(&buffer[0]))[:len(data)], data)
+	sd := (*windows.SECURITY_DESCRIPTOR)(unsafe.Pointer(&buffer[0]))
+	if !sd.IsValid() || int(sd.Length()) > len(data) {
+		return nil
+	}
+	return sd
+}
+
+// PropertyKey interprets v as a DEVPROPKEY.
+func (v Value) PropertyKey() Key {
+	return Key{
+		Category:   winguid.NativeEndian.GUID(v.array[:16]),
+		PropertyID: binary.LittleEndian.Uint32(v.array[16:20]),
+	}
+}
+
+// PropertyType interprets v as a DEVPROPTYPE.
+func (v Value) PropertyType() Type {
+	return Type(v.Uint32())
+}
+
+// Win32Error interprets v as a Win32 error code.
+func (v Value) Win32Error() windows.Errno {
+	return windows.Errno(v.Uint32())
+}
+
+// Status interprets v as an NTSTATUS code.
+func (v Value) Status() windows.NTStatus {
+	return windows.NTStatus(v.Uint32())
+}
+
 // Int8List interprets v as []int8.
 func (v Value) Int8List() []int8 {
 	data := v.Bytes()
@@ -360,11 +502,88 @@ func (v Value) TimeList() []time.Time {
 	list := make([]time.Time, count)
 	for i := 0; i < count; i++ {
 		offset := i * 8
-		filetime := windows.Filetime{
-			LowDateTime:  binary.LittleEndian.Uint32(data[offset : offset+4]),
-			HighDateTime: binary.LittleEndian.Uint32(data[offset+4 : offset+8]),
+		list[i] = devpropdata.FromFileTime(binary.LittleEndian.Uint64(data[offset : offset+8])).Local()
+	}
+	return list
+}
+
+// DecimalList interprets v as a list of DECIMAL values.
+func (v Value) DecimalList() []*big.Rat {
+	data := v.Bytes()
+	count := len(data) / 16
+	list := make([]*big.Rat, count)
+	for i := 0; i < count; i++ {
+		list[i] = decodeDecimal(data[i*16 : i*16+16])
+	}
+	return list
+}
+
+// CurrencyList interprets v as a list of CY values.
+func (v Value) CurrencyList() []CurrencyAmount {
+	data := v.Bytes()
+	count := len(data) / 8
+	list := make([]CurrencyAmount, count)
+	for i := 0; i < count; i++ {
+		list[i] = CurrencyAmount(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return list
+}
+
+// DateList interprets v as a list of OLE automation dates.
+func (v Value) DateList() []time.Time {
+	data := v.Bytes()
+	count := len(data) / 8
+	list := make([]time.Time, count)
+	for i := 0; i < count; i++ {
+		list[i] = decodeDate(math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:])))
+	}
+	return list
+}
+
+// PropertyKeyList interprets v as []Key.
+func (v Value) PropertyKeyList() []Key {
+	data := v.Bytes()
+	count := len(data) / 20
+	list := make([]Key, count)
+	for i := 0; i < count; i++ {
+		offset := i * 20
+		list[i] = Key{
+			Category:   winguid.NativeEndian.GUID(data[offset : offset+16]),
+			PropertyID: binary.LittleEndian.Uint32(data[offset+16 : offset+20]),
 		}
-		list[i] = time.Unix(0, filetime.Nanoseconds())
+	}
+	return list
+}
+
+// PropertyTypeList interprets v as []Type.
+func (v Value) PropertyTypeList() []Type {
+	data := v.Bytes()
+	count := len(data) / 4
+	list := make([]Type, count)
+	for i := 0; i < count; i++ {
+		list[i] = Type(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return list
+}
+
+// Win32ErrorList interprets v as a list of Win32 error codes.
+func (v Value) Win32ErrorList() []windows.Errno {
+	data := v.Bytes()
+	count := len(data) / 4
+	list := make([]windows.Errno, count)
+	for i := 0; i < count; i++ {
+		list[i] = windows.Errno(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return list
+}
+
+// StatusList interprets v as a list of NTSTATUS codes.
+func (v Value) StatusList() []windows.NTStatus {
+	data := v.Bytes()
+	count := len(data) / 4
+	list := make([]windows.NTStatus, count)
+	for i := 0; i < count; i++ {
+		list[i] = windows.NTStatus(binary.LittleEndian.Uint32(data[i*4:]))
 	}
 	return list
 }
@@ -378,3 +597,67 @@ func (v Value) BoolList() []bool {
 	}
 	return list
 }
+
+// securityDescriptorMinLength is the size of a self-relative security
+// descriptor header.
+const securityDescriptorMinLength = 20 // SECURITY_DESCRIPTOR_MIN_LENGTH
+
+// oleDateEpoch is the zero value of an OLE automation date.
+var oleDateEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// decodeDecimal converts a 16-byte DECIMAL structure to a rational number.
+//
+// https://docs.microsoft.com/en-us/windows/win32/api/wtypes/ns-wtypes-decimal-r1
+func decodeDecimal(data []byte) *big.Rat {
+	scale := data[2]
+	negative := data[3]&0x80 != 0
+	hi := binary.LittleEndian.Uint32(data[4:8])
+	lo := binary.LittleEndian.Uint64(data[8:16])
+
+	n := new(big.Int).SetUint64(uint64(hi))
+	n.Lsh(n, 64)
+	n.Or(n, new(big.Int).SetUint64(lo))
+	if negative {
+		n.Neg(n)
+	}
+	d := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	return new(big.Rat).SetFrac(n, d)
+}
+
+// formatDecimal returns the exact decimal representation of a DECIMAL
+// value, including trailing zeros implied by its scale.
+func formatDecimal(data []byte) string {
+	scale := int(data[2])
+	n := new(big.Int).SetUint64(uint64(binary.LittleEndian.Uint32(data[4:8])))
+	n.Lsh(n, 64)
+	n.Or(n, new(big.Int).SetUint64(binary.LittleEndian.Uint64(data[8:16])))
+
+	digits := n.String()
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if data[3]&0x80 != 0 {
+		digits = "-" + digits
+	}
+	return digits
+}
+
+// decodeDate converts an OLE automation date, which counts days since
+// midnight on December 30, 1899, to a time. The fractional part is the
+// time of day, even for dates before the epoch.
+//
+// https://docs.microsoft.com/en-us/dotnet/api/system.datetime.tooadate
+func decodeDate(days float64) time.Time {
+	whole, frac := math.Modf(days)
+	ms := math.Round(math.Abs(frac) * 24 * 60 * 60 * 1000)
+	return oleDateEpoch.AddDate(0, 0, int(whole)).Add(time.Duration(ms) * time.Millisecond)
+}
+
+// formatCode returns a string representation of a Win32 error or NTSTATUS
+// code that includes its numeric value and message.
+func formatCode(code uint32, err error) string {
+	return fmt.Sprintf("0x%08X: %v", code, err)
+}
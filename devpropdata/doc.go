@@ -0,0 +1,8 @@
+// Package devpropdata encodes the data of device property values.
+//
+// It does not depend on any Windows APIs, so it builds and can be tested on
+// any operating system. The deviceproperty package uses it to construct
+// values.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/property-data-type-identifiers
+package devpropdata
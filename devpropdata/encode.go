@@ -0,0 +1,122 @@
+package devpropdata
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Boolean values as stored in DEVPROP_TYPE_BOOLEAN data.
+const (
+	False = 0x00 // DEVPROP_FALSE
+	True  = 0xFF // DEVPROP_TRUE
+)
+
+const (
+	// fileTimeEpoch is the number of seconds between the FILETIME epoch of
+	// January 1, 1601 and the Unix epoch.
+	fileTimeEpoch = 11644473600
+
+	// fileTimeTicks is the number of FILETIME intervals in a second.
+	fileTimeTicks = 10000000
+)
+
+// Bool returns the 1-byte encoding of b.
+func Bool(b bool) []byte {
+	if b {
+		return []byte{True}
+	}
+	return []byte{False}
+}
+
+// Uint16 returns the 2-byte little-endian encoding of v.
+func Uint16(v uint16) []byte {
+	data := make([]byte, 2)
+	binary.LittleEndian.PutUint16(data, v)
+	return data
+}
+
+// Uint32 returns the 4-byte little-endian encoding of v.
+func Uint32(v uint32) []byte {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, v)
+	return data
+}
+
+// Uint64 returns the 8-byte little-endian encoding of v.
+func Uint64(v uint64) []byte {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, v)
+	return data
+}
+
+// FileTime returns the 8-byte FILETIME encoding of t. It returns an error
+// if t is outside of the FILETIME range. Precision beyond 100 nanoseconds
+// is truncated.
+func FileTime(t time.Time) ([]byte, error) {
+	ft, err := ToFileTime(t)
+	if err != nil {
+		return nil, err
+	}
+	return Uint64(ft), nil
+}
+
+// ToFileTime converts t to a FILETIME value, which counts 100-nanosecond
+// intervals since January 1, 1601 UTC. It returns an error if t is outside
+// of the FILETIME range. Precision beyond 100 nanoseconds is truncated.
+func ToFileTime(t time.Time) (uint64, error) {
+	seconds := t.Unix() + fileTimeEpoch
+	if t.Unix() < -fileTimeEpoch || uint64(seconds) >= math.MaxUint64/fileTimeTicks {
+		return 0, fmt.Errorf("%v is outside of the FILETIME range", t)
+	}
+	return uint64(seconds)*fileTimeTicks + uint64(t.Nanosecond()/100), nil
+}
+
+// FromFileTime converts a FILETIME value to a time in UTC.
+func FromFileTime(ft uint64) time.Time {
+	return time.Unix(int64(ft/fileTimeTicks)-fileTimeEpoch, int64(ft%fileTimeTicks)*100).UTC()
+}
+
+// String returns the null-terminated UTF-16LE encoding of s. It returns an
+// error if s contains a null character.
+func String(s string) ([]byte, error) {
+	return encodeStrings([]string{s}, false)
+}
+
+// StringList returns the encoding of a list of strings. Each string is
+// encoded as null-terminated UTF-16LE and the list is terminated by an
+// additional null character. It returns an error if any of the strings are
+// empty or contain a null character, as those cannot be represented in a
+// list.
+func StringList(list []string) ([]byte, error) {
+	for i, s := range list {
+		if s == "" {
+			return nil, fmt.Errorf("string list entry %d is empty", i)
+		}
+	}
+	return encodeStrings(list, true)
+}
+
+// encodeStrings encodes each string in list as null-terminated UTF-16LE.
+// Lists are terminated by an additional null character.
+func encodeStrings(list []string, terminate bool) ([]byte, error) {
+	var chars []uint16
+	for _, s := range list {
+		if strings.IndexByte(s, 0) >= 0 {
+			return nil, fmt.Errorf("string \"%s\" contains a null character", s)
+		}
+		chars = append(chars, utf16.Encode([]rune(s))...)
+		chars = append(chars, 0)
+	}
+	if terminate {
+		chars = append(chars, 0)
+	}
+	data := make([]byte, len(chars)*2)
+	for i, c := range chars {
+		binary.LittleEndian.PutUint16(data[i*2:], c)
+	}
+	return data, nil
+}
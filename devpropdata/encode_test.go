@@ -0,0 +1,157 @@
+package devpropdata
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestIntegers(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want []byte
+	}{
+		{"Uint16", Uint16(0x0102), []byte{0x02, 0x01}},
+		{"Uint16Max", Uint16(math.MaxUint16), []byte{0xFF, 0xFF}},
+		{"Uint32", Uint32(0x01020304), []byte{0x04, 0x03, 0x02, 0x01}},
+		{"Uint32High", Uint32(0xFFFFFFFE), []byte{0xFE, 0xFF, 0xFF, 0xFF}},
+		{"Uint64", Uint64(0x0102030405060708), []byte{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01}},
+		{"Uint64Zero", Uint64(0), make([]byte, 8)},
+		{"BoolTrue", Bool(true), []byte{0xFF}},
+		{"BoolFalse", Bool(false), []byte{0x00}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !bytes.Equal(tt.got, tt.want) {
+				t.Errorf("got % x, want % x", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileTime(t *testing.T) {
+	// The last second that can be represented
+	lastSecond := int64(math.MaxUint64/fileTimeTicks) - 1 - fileTimeEpoch
+
+	tests := []struct {
+		name string
+		time time.Time
+		want uint64
+	}{
+		{"Epoch", time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"EpochPlusTick", time.Date(1601, 1, 1, 0, 0, 0, 100, time.UTC), 1},
+		{"UnixEpoch", time.Unix(0, 0), fileTimeEpoch * fileTimeTicks},
+		{"Recent", time.Date(2020, 1, 2, 3, 4, 5, 600, time.UTC), 132224078450000006},
+		{"AfterYear2262", time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), 441481536000000000},
+		{"LastSecond", time.Unix(lastSecond, 0), uint64(lastSecond+fileTimeEpoch) * fileTimeTicks},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft, err := ToFileTime(tt.time)
+			if err != nil {
+				t.Fatalf("ToFileTime(%v): %v", tt.time, err)
+			}
+			if ft != tt.want {
+				t.Errorf("ToFileTime(%v) = %d, want %d", tt.time, ft, tt.want)
+			}
+			if back := FromFileTime(ft); !back.Equal(tt.time) {
+				t.Errorf("FromFileTime(%d) = %v, want %v", ft, back, tt.time)
+			}
+			data, err := FileTime(tt.time)
+			if err != nil {
+				t.Fatalf("FileTime(%v): %v", tt.time, err)
+			}
+			if want := Uint64(tt.want); !bytes.Equal(data, want) {
+				t.Errorf("FileTime(%v) = % x, want % x", tt.time, data, want)
+			}
+		})
+	}
+}
+
+func TestFileTimeTruncation(t *testing.T) {
+	ft, err := ToFileTime(time.Date(1601, 1, 1, 0, 0, 0, 199, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ft != 1 {
+		t.Errorf("got %d, want 1", ft)
+	}
+}
+
+func TestFileTimeRange(t *testing.T) {
+	lastSecond := int64(math.MaxUint64/fileTimeTicks) - 1 - fileTimeEpoch
+
+	tests := []struct {
+		name string
+		time time.Time
+	}{
+		{"BeforeEpoch", time.Date(1600, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{"Year1500", time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"AfterLastSecond", time.Unix(lastSecond+1, 0)},
+		{"FarFuture", time.Unix(math.MaxInt64-fileTimeEpoch, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ft, err := ToFileTime(tt.time); err == nil {
+				t.Errorf("ToFileTime(%v) = %d, want an error", tt.time, ft)
+			}
+			if _, err := FileTime(tt.time); err == nil {
+				t.Errorf("FileTime(%v) did not return an error", tt.time)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []byte
+		wantErr bool
+	}{
+		{"Empty", "", []byte{0, 0}, false},
+		{"ASCII", "ab", []byte{'a', 0, 'b', 0, 0, 0}, false},
+		{"Surrogate", "\U0001F600", []byte{0x3D, 0xD8, 0x00, 0xDE, 0, 0}, false},
+		{"Null", "a\x00b", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := String(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("String(%q) error = %v, want error %t", tt.value, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("String(%q) = % x, want % x", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringList(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    []string
+		want    []byte
+		wantErr bool
+	}{
+		{"Nil", nil, []byte{0, 0}, false},
+		{"One", []string{"a"}, []byte{'a', 0, 0, 0, 0, 0}, false},
+		{"Two", []string{"a", "bc"}, []byte{'a', 0, 0, 0, 'b', 0, 'c', 0, 0, 0, 0, 0}, false},
+		{"EmptyEntry", []string{"a", ""}, nil, true},
+		{"OnlyEmpty", []string{""}, nil, true},
+		{"NullEntry", []string{"a\x00b"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StringList(tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StringList(%q) error = %v, want error %t", tt.list, err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("StringList(%q) = % x, want % x", tt.list, got, tt.want)
+			}
+		})
+	}
+}
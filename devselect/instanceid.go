@@ -0,0 +1,14 @@
+package devselect
+
+import "github.com/gentlemanautomaton/windevice"
+
+// InstanceID returns a selector that matches device instance identifiers.
+func InstanceID(matcher StringMatcher) Selector {
+	return func(device windevice.Device) (bool, error) {
+		id, err := device.DeviceInstanceID()
+		if err != nil {
+			return false, err
+		}
+		return matcher.Match(string(id)), nil
+	}
+}
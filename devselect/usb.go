@@ -0,0 +1,35 @@
+package devselect
+
+import (
+	"github.com/gentlemanautomaton/windevice"
+	"github.com/gentlemanautomaton/windevice/deviceid"
+	"github.com/gentlemanautomaton/windevice/setupapi"
+)
+
+// USB returns a selector that matches devices with a USB or HID hardware
+// identifier for the given vendor and product IDs.
+func USB(vendor, product uint16) Selector {
+	return func(device windevice.Device) (bool, error) {
+		ids, err := device.HardwareID()
+		if err != nil {
+			if err == setupapi.ErrInvalidData {
+				return false, nil
+			}
+			return false, err
+		}
+		for _, id := range ids {
+			if usb, err := deviceid.ParseUSB(string(id)); err == nil {
+				if usb.HasVendor && usb.HasProduct && usb.VendorID == vendor && usb.ProductID == product {
+					return true, nil
+				}
+				continue
+			}
+			if hid, err := deviceid.ParseHID(string(id)); err == nil {
+				if hid.HasVendor && hid.HasProduct && hid.VendorID == vendor && hid.ProductID == product {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}
+}
@@ -0,0 +1,7 @@
+// Package driverindex builds a searchable index of the driver packages in
+// a directory tree.
+//
+// The index maps hardware and compatible IDs to the INF files that offer
+// drivers for them. It can be saved as JSON and rescanned incrementally.
+// INF files are only parsed again when their SHA-256 hash changes.
+package driverindex
@@ -0,0 +1,203 @@
+package driverindex
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FormatVersion is the version of the JSON index format written by Save.
+const FormatVersion = 1
+
+// Index is a searchable index of driver packages.
+//
+// The zero value is an empty index that is ready to use.
+type Index struct {
+	Format   int       `json:"format"`
+	Packages []Package `json:"packages"` // Sorted by path
+
+	ids map[string][]ref // Lower case ID to the models that list it
+}
+
+// ref identifies a models entry within the index.
+type ref struct {
+	pkg   int // Index into Packages
+	model int // Index into the package's Models
+}
+
+// Match is a models entry that lists a particular ID.
+type Match struct {
+	Package *Package
+	Model   *Model
+
+	// Compatible is true if the ID matched one of the model's compatible
+	// IDs rather than its hardware ID.
+	Compatible bool
+}
+
+// Load reads an index that was previously written with Save.
+func Load(path string) (*Index, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("unable to parse driver index \"%s\": %v", path, err)
+	}
+	if idx.Format > FormatVersion {
+		return nil, fmt.Errorf("driver index \"%s\" has unsupported format %d", path, idx.Format)
+	}
+	return &idx, nil
+}
+
+// Save writes the index to path as JSON.
+func (idx *Index) Save(path string) error {
+	idx.Format = FormatVersion
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ScanResult summarizes the changes made by a scan.
+type ScanResult struct {
+	Added     int
+	Updated   int
+	Unchanged int
+	Removed   int
+	Failed    int // Packages with INF files that could not be parsed
+	Warned    int // Packages with INF files that have an invalid [Version] section
+	Skipped   int // Packages with INF files that do not offer any devices
+}
+
+// Scan walks the directory tree at root and indexes every INF file it
+// finds. INF files with the same path and hash as a package already in the
+// index are not parsed again. Packages that are no longer present are
+// removed from the index.
+//
+// Paths in the index are relative to root, so the same root should be
+// used each time an index is scanned.
+func (idx *Index) Scan(root string) (ScanResult, error) {
+	var result ScanResult
+
+	existing := make(map[string]Package, len(idx.Packages))
+	for _, pkg := range idx.Packages {
+		existing[strings.ToLower(pkg.Path)] = pkg
+	}
+
+	var packages []Package
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".inf") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+
+		key := strings.ToLower(rel)
+		prev, found := existing[key]
+		delete(existing, key)
+
+		var pkg Package
+		switch {
+		case found && prev.Hash == hash:
+			pkg = prev
+			result.Unchanged++
+		case found:
+			pkg = readPackage(rel, hash, data)
+			result.Updated++
+		default:
+			pkg = readPackage(rel, hash, data)
+			result.Added++
+		}
+		if pkg.Error != "" {
+			result.Failed++
+		}
+		if pkg.Warning != "" {
+			result.Warned++
+		}
+		if pkg.Skipped != "" {
+			result.Skipped++
+		}
+		packages = append(packages, pkg)
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	result.Removed = len(existing)
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Path < packages[j].Path
+	})
+	idx.Packages = packages
+	idx.ids = nil
+
+	return result, nil
+}
+
+// Lookup returns the models entries that list id as a hardware or
+// compatible ID. The comparison is not case-sensitive.
+func (idx *Index) Lookup(id string) []Match {
+	if idx.ids == nil {
+		idx.build()
+	}
+	refs := idx.ids[strings.ToLower(id)]
+	matches := make([]Match, 0, len(refs))
+	for _, r := range refs {
+		pkg := &idx.Packages[r.pkg]
+		model := &pkg.Models[r.model]
+		matches = append(matches, Match{
+			Package:    pkg,
+			Model:      model,
+			Compatible: !strings.EqualFold(string(model.HardwareID), id),
+		})
+	}
+	return matches
+}
+
+// build prepares the ID lookup table.
+func (idx *Index) build() {
+	idx.ids = make(map[string][]ref)
+	add := func(id string, r ref) {
+		if id == "" {
+			return
+		}
+		key := strings.ToLower(id)
+		refs := idx.ids[key]
+		if n := len(refs); n > 0 && refs[n-1] == r {
+			return // The same ID is listed twice in one entry
+		}
+		idx.ids[key] = append(refs, r)
+	}
+	for p := range idx.Packages {
+		for m, model := range idx.Packages[p].Models {
+			r := ref{pkg: p, model: m}
+			add(string(model.HardwareID), r)
+			for _, id := range model.CompatibleIDs {
+				add(string(id), r)
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package driverindex
+
+import (
+	"time"
+
+	"github.com/gentlemanautomaton/windevice/deviceid"
+	"github.com/gentlemanautomaton/windevice/driverversion"
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+// Package describes a single INF file in the index.
+type Package struct {
+	// Path is the location of the INF file relative to the root of the
+	// index, with forward slashes.
+	Path string `json:"path"`
+
+	// Hash is the hex-encoded SHA-256 hash of the INF file.
+	Hash string `json:"sha256"`
+
+	Class     string              `json:"class,omitempty"`
+	ClassGUID string              `json:"classGuid,omitempty"`
+	Provider  string              `json:"provider,omitempty"`
+	Date      time.Time           `json:"date"`
+	Version   driverversion.Value `json:"version"`
+	Models    []Model             `json:"models,omitempty"`
+
+	// Error is set when the INF file could not be parsed.
+	Error string `json:"error,omitempty"`
+
+	// Warning is set when the INF file was parsed but part of its
+	// [Version] section is invalid, such as a malformed DriverVer entry.
+	// The package is still indexed.
+	Warning string `json:"warning,omitempty"`
+
+	// Skipped is set when the INF file was parsed but does not offer any
+	// devices, such as an INF file without a [Manufacturer] section.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Model is a models entry offered by a package.
+type Model struct {
+	Manufacturer   string                `json:"manufacturer,omitempty"`
+	Section        string                `json:"section"`
+	Architecture   inf.Architecture      `json:"architecture"`
+	Description    string                `json:"description,omitempty"`
+	InstallSection string                `json:"installSection"`
+	HardwareID     deviceid.Hardware     `json:"hardwareId"`
+	CompatibleIDs  []deviceid.Compatible `json:"compatibleIds,omitempty"`
+}
+
+// readPackage parses the INF file data and returns a package describing
+// it. Parse failures are recorded in the package's Error field, and
+// problems with the [Version] section in its Warning field. INF files
+// without a [Manufacturer] section are recorded in the Skipped field and
+// have no models.
+func readPackage(path, hash string, data []byte) Package {
+	pkg := Package{Path: path, Hash: hash}
+
+	doc, err := inf.ParseBytes(data)
+	if err != nil {
+		pkg.Error = err.Error()
+		return pkg
+	}
+
+	version, err := doc.Version()
+	if err != nil {
+		pkg.Warning = err.Error()
+	}
+	pkg.Class = version.Class
+	pkg.ClassGUID = version.ClassGUID
+	pkg.Provider = version.Provider
+	pkg.Date = version.DriverDate
+	pkg.Version = version.DriverVersion
+
+	if !doc.HasSection("Manufacturer") {
+		pkg.Skipped = "the inf file does not have a [Manufacturer] section"
+		return pkg
+	}
+
+	for _, m := range doc.Manufacturers() {
+		for _, name := range doc.ModelsSections(m) {
+			arch, err := m.Architecture(name)
+			if err != nil {
+				continue
+			}
+			for _, model := range doc.ModelsIn(m, name) {
+				pkg.Models = append(pkg.Models, Model{
+					Manufacturer:   model.Manufacturer,
+					Section:        model.Section,
+					Architecture:   arch,
+					Description:    model.Description,
+					InstallSection: model.InstallSection,
+					HardwareID:     model.HardwareID,
+					CompatibleIDs:  model.CompatibleIDs,
+				})
+			}
+		}
+	}
+
+	return pkg
+}
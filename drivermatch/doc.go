@@ -0,0 +1,8 @@
+// Package drivermatch simulates the driver selection that Windows performs
+// for a device, using INF files on disk instead of the driver store.
+//
+// Candidates are read from the models sections that apply to a target
+// platform and ranked with the driverrank package. Because signatures are
+// not verified offline, the signer score of each package is either
+// supplied by the caller or inferred from the presence of a catalog file.
+package drivermatch
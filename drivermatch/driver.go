@@ -0,0 +1,59 @@
+package drivermatch
+
+import (
+	"time"
+
+	"github.com/gentlemanautomaton/windevice/driverrank"
+	"github.com/gentlemanautomaton/windevice/driverversion"
+)
+
+// Driver is a candidate driver that matched a device. Its accessors mirror
+// those of windevice.Driver, so that simulated results can be compared
+// with the compatible driver list that Windows builds for a device.
+type Driver struct {
+	// Match describes how the driver matched the device and the rank it
+	// received.
+	Match driverrank.Match
+
+	// InfPath is the path of the INF file that offers the driver.
+	InfPath string
+
+	// ModelsSection is the decorated models section that lists the driver,
+	// and InstallSection is the install section that applies to the
+	// platform.
+	ModelsSection  string
+	InstallSection string
+
+	Manufacturer string
+	Provider     string
+}
+
+// Description returns the description of the driver.
+func (d Driver) Description() string {
+	return d.Match.Candidate.Description
+}
+
+// ManufacturerName returns the name of the driver's manufacturer.
+func (d Driver) ManufacturerName() string {
+	return d.Manufacturer
+}
+
+// ProviderName returns the name of the driver provider.
+func (d Driver) ProviderName() string {
+	return d.Provider
+}
+
+// Date returns the release date of the driver.
+func (d Driver) Date() time.Time {
+	return d.Match.Candidate.Date
+}
+
+// Version returns the version of the driver.
+func (d Driver) Version() driverversion.Value {
+	return d.Match.Candidate.Version
+}
+
+// Rank returns the rank that the driver received.
+func (d Driver) Rank() driverrank.Rank {
+	return d.Match.Rank
+}
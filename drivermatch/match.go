@@ -0,0 +1,106 @@
+package drivermatch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/driverrank"
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+// Options control how candidate drivers are read and ranked.
+type Options struct {
+	// Platform is the version of Windows being simulated. It determines
+	// which models and install sections apply.
+	Platform inf.Platform
+
+	// Signer is the signer score given to every package. When it is zero,
+	// packages with a catalog file for the platform's architecture are
+	// treated as driverrank.Authenticode and the rest as
+	// driverrank.Unsigned.
+	Signer driverrank.Signer
+}
+
+// Drivers returns every driver offered by sources that matches the device
+// on the platform, ordered from best to worst. The first driver is the one
+// Windows would select.
+func Drivers(device driverrank.Device, sources []Source, opts Options) []Driver {
+	var (
+		candidates []driverrank.Candidate
+		details    []Driver // Driver details for each candidate, by order
+	)
+
+	for _, source := range sources {
+		doc := source.Document
+		version, _ := doc.Version()
+
+		signer := opts.Signer
+		if signer == 0 {
+			if version.CatalogFile(opts.Platform.Architecture) != "" {
+				signer = driverrank.Authenticode
+			} else {
+				signer = driverrank.Unsigned
+			}
+		}
+
+		for _, model := range doc.Models(opts.Platform) {
+			candidate := driverrank.Candidate{
+				Description:   model.Description,
+				HardwareID:    model.HardwareID,
+				CompatibleIDs: model.CompatibleIDs,
+				Signer:        signer,
+				Date:          version.DriverDate,
+				Version:       version.DriverVersion,
+			}
+			detail := Driver{
+				InfPath:       source.Path,
+				ModelsSection: model.Section,
+				Manufacturer:  model.Manufacturer,
+				Provider:      version.Provider,
+			}
+			if install := doc.InstallSection(model.InstallSection, opts.Platform.Architecture); install != nil {
+				detail.InstallSection = install.Name
+				if score, ok := featureScore(install); ok {
+					candidate.FeatureScore, candidate.HasFeatureScore = score, true
+				}
+			}
+			candidates = append(candidates, candidate)
+			details = append(details, detail)
+		}
+	}
+
+	matches := device.Rank(candidates)
+	drivers := make([]Driver, 0, len(matches))
+	for _, match := range matches {
+		driver := details[match.Order]
+		driver.Match = match
+		drivers = append(drivers, driver)
+	}
+	return drivers
+}
+
+// Best returns the driver that Windows would select for the device. It
+// returns false if no driver matches.
+func Best(device driverrank.Device, sources []Source, opts Options) (Driver, bool) {
+	drivers := Drivers(device, sources, opts)
+	if len(drivers) == 0 {
+		return Driver{}, false
+	}
+	return drivers[0], true
+}
+
+// featureScore returns the value of the FeatureScore directive in an
+// install section.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-featurescore-directive
+func featureScore(install *inf.Section) (uint8, bool) {
+	value := strings.TrimSpace(install.Value("FeatureScore"))
+	if value == "" {
+		return 0, false
+	}
+	score, err := strconv.ParseUint(value, 0, 8)
+	if err != nil {
+		return 0, false
+	}
+	return uint8(score), true
+}
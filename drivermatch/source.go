@@ -0,0 +1,77 @@
+package drivermatch
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+// Source is a parsed INF file that can supply candidate drivers.
+type Source struct {
+	Path     string
+	Document *inf.Document
+}
+
+// LoadError records an INF file or directory that could not be read or
+// parsed by Load.
+type LoadError struct {
+	Path string
+	Err  error
+}
+
+// Error returns a string describing the error.
+func (e LoadError) Error() string {
+	return fmt.Sprintf("\"%s\": %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e LoadError) Unwrap() error {
+	return e.Err
+}
+
+// Load parses the INF files named by paths. Directories are searched
+// recursively for INF files.
+//
+// An INF file that cannot be read or parsed does not stop the search. It is
+// returned in failed along with the sources that were loaded. An error is
+// only returned if one of the paths themselves cannot be accessed.
+func Load(paths ...string) (sources []Source, failed []LoadError, err error) {
+	add := func(path string) {
+		doc, err := inf.Load(path)
+		if err != nil {
+			failed = append(failed, LoadError{Path: path, Err: err})
+			return
+		}
+		sources = append(sources, Source{Path: path, Document: doc})
+	}
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !fi.IsDir() {
+			add(path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// Record unreadable subdirectories and keep going
+				failed = append(failed, LoadError{Path: p, Err: err})
+				return nil
+			}
+			if d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".inf") {
+				return nil
+			}
+			add(p)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return sources, failed, nil
+}
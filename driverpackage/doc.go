@@ -0,0 +1,8 @@
+// Package driverpackage inspects the files that make up a driver package.
+//
+// A driver package is a directory that holds an INF file along with the
+// catalog and binary files that it installs. The package resolves the
+// CopyFiles directives of the INF file to the files on disk, so that
+// missing or unreferenced files can be found before the package is
+// deployed.
+package driverpackage
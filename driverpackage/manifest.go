@@ -0,0 +1,341 @@
+package driverpackage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+// Manifest lists the files that a driver package needs on a particular
+// processor architecture.
+type Manifest struct {
+	// InfPath is the path of the INF file, and Dir is the package
+	// directory that contains it.
+	InfPath string
+	Dir     string
+
+	Architecture inf.Architecture
+
+	// Files lists every file copied by the install sections that apply to
+	// the architecture, in the order they are first referenced.
+	Files []File
+
+	// Catalogs lists the catalog files named by the INF file for the
+	// architecture.
+	Catalogs []File
+
+	// Unreferenced lists files in the package directory that are not used
+	// by the INF file, relative to Dir with forward slashes. It is only
+	// populated by Check.
+	Unreferenced []string
+}
+
+// File is a file required by a driver package.
+type File struct {
+	// Name is the name of the file in the package, and Destination is the
+	// name it is given when copied.
+	Name        string
+	Destination string
+
+	// SourcePath is the location of the file relative to the package
+	// directory, with forward slashes. It is resolved through the
+	// [SourceDisksNames] and [SourceDisksFiles] sections. When the file
+	// exists, the path matches the case of the file on disk.
+	SourcePath string
+
+	// DirID and Subdir identify the destination directory, as given by
+	// the [DestinationDirs] section. DirID is zero if no destination
+	// applies.
+	DirID  int
+	Subdir string
+
+	// Sections lists the install sections that copy the file.
+	Sections []string
+
+	// Listed is false when the file is not listed in [SourceDisksFiles].
+	Listed bool
+
+	// Missing is true when the file does not exist in the package
+	// directory. Size and SHA256 are only populated when it exists.
+	Missing bool
+	Size    int64
+	SHA256  string
+}
+
+// Build reads the INF file at infPath and returns the manifest of files it
+// needs on the given architecture. Each file is hashed if it exists.
+func Build(infPath string, arch inf.Architecture) (Manifest, error) {
+	doc, err := inf.Load(infPath)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return BuildFrom(doc, infPath, arch), nil
+}
+
+// BuildFrom returns the manifest of files that a parsed INF file needs on
+// the given architecture. The package directory is the directory that
+// contains infPath. Each file is hashed if it exists.
+func BuildFrom(doc *inf.Document, infPath string, arch inf.Architecture) Manifest {
+	return buildFrom(doc, infPath, arch, true)
+}
+
+// ListFrom returns the manifest of files that a parsed INF file needs on
+// the given architecture, like BuildFrom, but only checks whether each file
+// exists. Size and SHA256 are not populated, so no file contents are read.
+func ListFrom(doc *inf.Document, infPath string, arch inf.Architecture) Manifest {
+	return buildFrom(doc, infPath, arch, false)
+}
+
+func buildFrom(doc *inf.Document, infPath string, arch inf.Architecture, hash bool) Manifest {
+	m := Manifest{
+		InfPath:      infPath,
+		Dir:          filepath.Dir(infPath),
+		Architecture: arch,
+	}
+
+	index := make(map[string]int) // Lower case source path to index in Files
+	for _, install := range installSections(doc, arch) {
+		for _, copied := range doc.CopyFiles(install) {
+			file := resolve(doc, arch, copied)
+			key := strings.ToLower(file.SourcePath)
+			if i, exists := index[key]; exists {
+				m.Files[i].Sections = appendUnique(m.Files[i].Sections, install.Name)
+				continue
+			}
+			file.Sections = []string{install.Name}
+			index[key] = len(m.Files)
+			m.Files = append(m.Files, file)
+		}
+	}
+
+	// A DriverVer error doesn't prevent the catalog files from being read
+	version, _ := doc.Version()
+	if name := version.CatalogFile(arch); name != "" {
+		m.Catalogs = append(m.Catalogs, File{Name: name, Destination: name, SourcePath: name, Listed: true})
+	}
+
+	// Windows file names are not case-sensitive, but the file system that
+	// holds the package might be
+	files, _ := listFiles(m.Dir)
+	for _, list := range [][]File{m.Files, m.Catalogs} {
+		for i := range list {
+			if hash {
+				hashFile(m.Dir, files, &list[i])
+			} else {
+				locateFile(files, &list[i])
+			}
+		}
+	}
+
+	return m
+}
+
+// Check builds a manifest like Build and also lists the files in the
+// package directory that the INF file does not use. The INF file itself
+// is not considered unreferenced.
+func Check(infPath string, arch inf.Architecture) (Manifest, error) {
+	m, err := Build(infPath, arch)
+	if err != nil {
+		return m, err
+	}
+
+	used := make(map[string]bool)
+	used[strings.ToLower(filepath.Base(infPath))] = true
+	for _, f := range m.Files {
+		used[strings.ToLower(f.SourcePath)] = true
+	}
+	for _, f := range m.Catalogs {
+		used[strings.ToLower(f.SourcePath)] = true
+	}
+
+	files, err := listFiles(m.Dir)
+	for lower, rel := range files {
+		if !used[lower] {
+			m.Unreferenced = append(m.Unreferenced, rel)
+		}
+	}
+	sort.Strings(m.Unreferenced)
+
+	return m, err
+}
+
+// MissingFiles returns the files and catalogs that do not exist in the
+// package directory.
+func (m Manifest) MissingFiles() []File {
+	var missing []File
+	for _, f := range append(append([]File(nil), m.Files...), m.Catalogs...) {
+		if f.Missing {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// installSections returns the install sections used by the models entries
+// that apply to the architecture, along with their CoInstallers sections.
+func installSections(doc *inf.Document, arch inf.Architecture) []*inf.Section {
+	var (
+		sections []*inf.Section
+		seen     = make(map[*inf.Section]bool)
+	)
+	add := func(section *inf.Section) {
+		if section != nil && !seen[section] {
+			seen[section] = true
+			sections = append(sections, section)
+		}
+	}
+	for _, m := range doc.Manufacturers() {
+		for _, name := range doc.ModelsSections(m) {
+			if target, err := m.Architecture(name); err != nil || target != arch {
+				continue
+			}
+			for _, model := range doc.ModelsIn(m, name) {
+				install := doc.InstallSection(model.InstallSection, arch)
+				add(install)
+				if install != nil {
+					add(doc.Section(install.Name + ".CoInstallers"))
+				}
+			}
+		}
+	}
+	return sections
+}
+
+// resolve determines the source path and destination directory of a
+// copied file.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-sourcedisksnames-section
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-destinationdirs-section
+func resolve(doc *inf.Document, arch inf.Architecture, copied inf.CopyFile) File {
+	file := File{
+		Name:        copied.Source,
+		Destination: copied.Destination,
+		SourcePath:  copied.Source,
+	}
+
+	if source, ok := doc.SourceFile(copied.Source, arch); ok {
+		file.Listed = true
+		dir := ""
+		if disk, ok := sourceDisk(doc, source.DiskID, arch); ok {
+			// diskid = disk-description[,[tag-or-cab-file],[unused],[path]...]
+			dir = disk.Value(3)
+		}
+		file.SourcePath = cleanPath(dir, source.Subdir, copied.Source)
+	}
+
+	destination := doc.Section("DestinationDirs")
+	entry, ok := destination.Entry(copied.Section)
+	if copied.Section == "" || !ok {
+		entry, ok = destination.Entry("DefaultDestDir")
+	}
+	if ok {
+		file.DirID, _ = strconv.Atoi(entry.Value(0))
+		file.Subdir = entry.Value(1)
+	}
+
+	return file
+}
+
+// sourceDisk looks up a disk in the [SourceDisksNames.architecture] and
+// [SourceDisksNames] sections, in that order.
+func sourceDisk(doc *inf.Document, id string, arch inf.Architecture) (inf.Entry, bool) {
+	for _, name := range []string{"SourceDisksNames." + string(arch), "SourceDisksNames"} {
+		if entry, ok := doc.Section(name).Entry(id); ok {
+			return entry, true
+		}
+	}
+	return inf.Entry{}, false
+}
+
+// cleanPath joins INF path elements, which use backslashes, into a
+// relative path with forward slashes.
+func cleanPath(elem ...string) string {
+	for i, e := range elem {
+		elem[i] = strings.Trim(strings.ReplaceAll(e, "\\", "/"), "/")
+	}
+	p := path.Join(elem...)
+	if p == "." {
+		return ""
+	}
+	return p
+}
+
+// listFiles returns the files in dir and its subdirectories. The map is
+// keyed by lower case relative path and holds the relative path with
+// forward slashes.
+func listFiles(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		files[strings.ToLower(rel)] = rel
+		return nil
+	})
+	return files, err
+}
+
+// locateFile matches the file's source path against files without regard
+// to case, and marks the file as missing if it is not found.
+func locateFile(files map[string]string, file *File) {
+	rel, ok := files[strings.ToLower(file.SourcePath)]
+	if !ok {
+		file.Missing = true
+		return
+	}
+	file.SourcePath = rel
+}
+
+// hashFile records the size and SHA-256 hash of a file in the package
+// directory, or marks it as missing. The file's source path is matched
+// against files without regard to case.
+func hashFile(dir string, files map[string]string, file *File) {
+	rel, ok := files[strings.ToLower(file.SourcePath)]
+	if !ok {
+		file.Missing = true
+		return
+	}
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		file.Missing = true
+		return
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		file.Missing = true
+		return
+	}
+	file.SourcePath = rel
+	file.Size = n
+	file.SHA256 = hex.EncodeToString(h.Sum(nil))
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, existing := range list {
+		if strings.EqualFold(existing, value) {
+			return list
+		}
+	}
+	return append(list, value)
+}
@@ -0,0 +1,50 @@
+package driverrank
+
+import (
+	"time"
+
+	"github.com/gentlemanautomaton/windevice/deviceid"
+	"github.com/gentlemanautomaton/windevice/driverversion"
+)
+
+// Device holds the identifiers reported by a device, in the order that
+// the device reports them.
+type Device struct {
+	HardwareIDs   []deviceid.Hardware
+	CompatibleIDs []deviceid.Compatible
+}
+
+// Candidate is a driver that could be selected for a device. It describes
+// a single entry in an INF models section.
+type Candidate struct {
+	// Description is the device description of the models entry.
+	Description string
+
+	// HardwareID is the first identifier listed in the models entry.
+	HardwareID deviceid.Hardware
+
+	// CompatibleIDs are the remaining identifiers listed in the models
+	// entry, in order.
+	CompatibleIDs []deviceid.Compatible
+
+	// FeatureScore is the value of the FeatureScore directive in the
+	// driver's install section. It is only used if HasFeatureScore is true,
+	// otherwise DefaultFeatureScore is used.
+	FeatureScore    uint8
+	HasFeatureScore bool
+
+	// Signer describes how the driver package is signed.
+	Signer Signer
+
+	// Date and Version are taken from the DriverVer directive of the INF.
+	Date    time.Time
+	Version driverversion.Value
+}
+
+// featureScore returns the feature score of the candidate.
+func (c Candidate) featureScore() uint8 {
+	if c.HasFeatureScore {
+		return c.FeatureScore
+	}
+	return DefaultFeatureScore
+}
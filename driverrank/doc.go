@@ -0,0 +1,13 @@
+// Package driverrank implements the algorithm that Windows uses to rank
+// candidate drivers for a device and select the best one.
+//
+// A driver rank has the form 0xSSGGTHHH, where SS is the signature score,
+// GG is the feature score, T is the identifier score and HHH is the
+// position of the matching identifiers. Lower ranks are better.
+//
+// When more than one driver shares the lowest rank, Windows chooses the
+// driver with the most recent date. If the dates are also equal it chooses
+// the driver with the highest version.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/how-setup-ranks-drivers--windows-vista-and-later-
+package driverrank
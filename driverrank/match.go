@@ -0,0 +1,102 @@
+package driverrank
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Match describes a candidate driver that matched a device and the rank it
+// received.
+type Match struct {
+	Candidate Candidate
+	Rank      Rank
+
+	// Order is the position of the candidate in the list of candidates
+	// that was evaluated.
+	Order int
+
+	// DeviceID is the device identifier that matched, and DeviceIndex is
+	// its position within the device's hardware or compatible ID list.
+	DeviceID    string
+	DeviceIndex int
+
+	// ModelID is the models entry identifier that matched. ModelIndex is
+	// zero when the hardware ID of the entry matched, otherwise it is one
+	// more than the position of the matching compatible ID in the entry.
+	ModelID    string
+	ModelIndex int
+}
+
+// Evaluate determines whether candidate matches device. If it does, it
+// returns the best match between their identifiers and true.
+//
+// Identifiers are compared without regard to case. The device's hardware
+// IDs are considered before its compatible IDs, and within each list
+// earlier identifiers are preferred.
+func Evaluate(device Device, candidate Candidate) (Match, bool) {
+	signature := candidate.Signer.SignatureScore()
+	feature := candidate.featureScore()
+
+	best := Match{}
+	found := false
+	consider := func(match MatchType, deviceID string, deviceIndex int, modelID string, modelIndex int) {
+		rank := newRank(signature, feature, match, deviceIndex, modelIndex)
+		if found && rank >= best.Rank {
+			return
+		}
+		best = Match{
+			Candidate:   candidate,
+			Rank:        rank,
+			DeviceID:    deviceID,
+			DeviceIndex: deviceIndex,
+			ModelID:     modelID,
+			ModelIndex:  modelIndex,
+		}
+		found = true
+	}
+
+	for i, id := range device.HardwareIDs {
+		if equalID(string(id), string(candidate.HardwareID)) {
+			consider(HardwareToHardware, string(id), i, string(candidate.HardwareID), 0)
+		}
+		for j, cid := range candidate.CompatibleIDs {
+			if equalID(string(id), string(cid)) {
+				consider(HardwareToCompatible, string(id), i, string(cid), j+1)
+			}
+		}
+	}
+
+	for i, id := range device.CompatibleIDs {
+		if equalID(string(id), string(candidate.HardwareID)) {
+			consider(CompatibleToHardware, string(id), i, string(candidate.HardwareID), 0)
+		}
+		for j, cid := range candidate.CompatibleIDs {
+			if equalID(string(id), string(cid)) {
+				consider(CompatibleToCompatible, string(id), i, string(cid), j+1)
+			}
+		}
+	}
+
+	return best, found
+}
+
+// String returns a description of the match.
+func (m Match) String() string {
+	var device, model string
+	switch m.Rank.MatchType() {
+	case HardwareToHardware, HardwareToCompatible:
+		device = "hardware ID"
+	default:
+		device = "compatible ID"
+	}
+	if m.ModelIndex == 0 {
+		model = "hardware ID"
+	} else {
+		model = fmt.Sprintf("compatible ID %d", m.ModelIndex)
+	}
+	return fmt.Sprintf("rank %s: device %s %d (%s) matched INF %s (%s)", m.Rank, device, m.DeviceIndex, m.DeviceID, model, m.ModelID)
+}
+
+func equalID(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
@@ -0,0 +1,31 @@
+package driverrank
+
+import "fmt"
+
+// MatchType identifies the kinds of identifiers that matched when a driver
+// was ranked. It is the identifier score (T) of a rank.
+type MatchType uint8
+
+// Identifier match types, from best to worst.
+const (
+	HardwareToHardware     MatchType = 0 // A device hardware ID matched an INF hardware ID
+	HardwareToCompatible   MatchType = 1 // A device hardware ID matched an INF compatible ID
+	CompatibleToHardware   MatchType = 2 // A device compatible ID matched an INF hardware ID
+	CompatibleToCompatible MatchType = 3 // A device compatible ID matched an INF compatible ID
+)
+
+// String returns a string representation of the match type.
+func (t MatchType) String() string {
+	switch t {
+	case HardwareToHardware:
+		return "HardwareToHardware"
+	case HardwareToCompatible:
+		return "HardwareToCompatible"
+	case CompatibleToHardware:
+		return "CompatibleToHardware"
+	case CompatibleToCompatible:
+		return "CompatibleToCompatible"
+	default:
+		return fmt.Sprintf("UnknownMatchType %d", t)
+	}
+}
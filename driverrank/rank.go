@@ -0,0 +1,63 @@
+package driverrank
+
+import "fmt"
+
+// Rank is a driver rank in the form 0xSSGGTHHH. Lower ranks are better.
+type Rank uint32
+
+// Signature returns the signature score (SS) of the rank.
+func (r Rank) Signature() uint8 {
+	return uint8(r >> 24)
+}
+
+// Feature returns the feature score (GG) of the rank.
+func (r Rank) Feature() uint8 {
+	return uint8(r >> 16)
+}
+
+// Identifier returns the identifier score and position (THHH) of the rank.
+func (r Rank) Identifier() uint16 {
+	return uint16(r)
+}
+
+// MatchType returns the kind of identifier match (T) that produced the rank.
+func (r Rank) MatchType() MatchType {
+	return MatchType((r >> 12) & 0xF)
+}
+
+// String returns a string representation of the rank in hexadecimal.
+func (r Rank) String() string {
+	return fmt.Sprintf("0x%08X", uint32(r))
+}
+
+// DefaultFeatureScore is the feature score assigned to drivers that don't
+// specify one with a FeatureScore directive.
+const DefaultFeatureScore = 0xFF
+
+// Limits on the positions that can be recorded in the HHH component of
+// a rank.
+const (
+	maxDevicePosition = 0xFF
+	maxModelPosition  = 0xF
+)
+
+// newRank assembles a rank from its components. The device index is the
+// position of the matching device identifier. The model index is zero for
+// a models entry hardware ID, or one more than the position of a models
+// entry compatible ID.
+func newRank(signature, feature uint8, match MatchType, deviceIndex, modelIndex int) Rank {
+	if deviceIndex > maxDevicePosition {
+		deviceIndex = maxDevicePosition
+	}
+
+	position := deviceIndex
+	if modelIndex > 0 {
+		compatIndex := modelIndex - 1
+		if compatIndex > maxModelPosition {
+			compatIndex = maxModelPosition
+		}
+		position |= compatIndex << 8
+	}
+
+	return Rank(uint32(signature)<<24 | uint32(feature)<<16 | uint32(match)<<12 | uint32(position))
+}
@@ -0,0 +1,113 @@
+package driverrank
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Criterion identifies the rule that decided between two matches.
+type Criterion int
+
+// Selection criteria, in the order that they are applied.
+const (
+	ByRank    Criterion = iota // The match with the lower rank wins
+	ByDate                     // The match with the more recent date wins
+	ByVersion                  // The match with the higher version wins
+	BySigner                   // The match with the better signer score wins
+	ByOrder                    // The match that was evaluated first wins
+)
+
+// String returns a string representation of the criterion.
+func (c Criterion) String() string {
+	switch c {
+	case ByRank:
+		return "ByRank"
+	case ByDate:
+		return "ByDate"
+	case ByVersion:
+		return "ByVersion"
+	case BySigner:
+		return "BySigner"
+	case ByOrder:
+		return "ByOrder"
+	default:
+		return fmt.Sprintf("UnknownCriterion %d", c)
+	}
+}
+
+// Compare compares two matches using the tie-break rules that Windows
+// applies when selecting a driver. It returns a negative number if a is
+// better than b, a positive number if b is better than a, and zero if they
+// are equivalent. It also returns the criterion that decided the outcome.
+//
+// Windows compares ranks first, then driver dates and finally driver
+// versions. Remaining ties are broken by signer score and then by the
+// order in which the candidates were evaluated, so that the outcome is
+// deterministic.
+func Compare(a, b Match) (int, Criterion) {
+	switch {
+	case a.Rank < b.Rank:
+		return -1, ByRank
+	case a.Rank > b.Rank:
+		return 1, ByRank
+	}
+
+	switch {
+	case a.Candidate.Date.After(b.Candidate.Date):
+		return -1, ByDate
+	case a.Candidate.Date.Before(b.Candidate.Date):
+		return 1, ByDate
+	}
+
+	switch {
+	case a.Candidate.Version > b.Candidate.Version:
+		return -1, ByVersion
+	case a.Candidate.Version < b.Candidate.Version:
+		return 1, ByVersion
+	}
+
+	switch {
+	case a.Candidate.Signer < b.Candidate.Signer:
+		return -1, BySigner
+	case a.Candidate.Signer > b.Candidate.Signer:
+		return 1, BySigner
+	}
+
+	switch {
+	case a.Order < b.Order:
+		return -1, ByOrder
+	case a.Order > b.Order:
+		return 1, ByOrder
+	}
+
+	return 0, ByOrder
+}
+
+// Rank evaluates each candidate against device and returns the candidates
+// that matched, sorted from best to worst.
+func (device Device) Rank(candidates []Candidate) []Match {
+	var matches []Match
+	for i, candidate := range candidates {
+		match, ok := Evaluate(device, candidate)
+		if !ok {
+			continue
+		}
+		match.Order = i
+		matches = append(matches, match)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		c, _ := Compare(matches[i], matches[j])
+		return c < 0
+	})
+	return matches
+}
+
+// Select evaluates each candidate against device and returns the match that
+// Windows would select. It returns false if none of the candidates match.
+func (device Device) Select(candidates []Candidate) (Match, bool) {
+	matches := device.Rank(candidates)
+	if len(matches) == 0 {
+		return Match{}, false
+	}
+	return matches[0], true
+}
@@ -0,0 +1,66 @@
+package driverrank
+
+import "fmt"
+
+// Signer is a signer score that describes how a driver package is signed.
+// Lower scores are better.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/signature-score--windows-vista-and-later-
+type Signer uint32
+
+// Signer scores, from best to worst.
+const (
+	LogoPremium  Signer = 0x0D000001 // SIGNERSCORE_LOGO_PREMIUM
+	LogoStandard Signer = 0x0D000002 // SIGNERSCORE_LOGO_STANDARD
+	Inbox        Signer = 0x0D000003 // SIGNERSCORE_INBOX
+	Unclassified Signer = 0x0D000004 // SIGNERSCORE_UNCLASSIFIED
+	WHQL         Signer = 0x0D000005 // SIGNERSCORE_WHQL
+	Authenticode Signer = 0x0F000000 // SIGNERSCORE_AUTHENTICODE
+	Unsigned     Signer = 0x80000000 // SIGNERSCORE_UNSIGNED
+	W9xSuspect   Signer = 0xC0000000 // SIGNERSCORE_W9X_SUSPECT
+	Unknown      Signer = 0xFF000000 // SIGNERSCORE_UNKNOWN
+)
+
+// Signed returns true if the signer score describes a signed driver.
+func (s Signer) Signed() bool {
+	return s < Unsigned
+}
+
+// SignatureScore returns the signature score (SS) that a driver with signer
+// score s receives in its rank.
+//
+// Windows treats all trusted signers equally when ranking drivers, so every
+// signed driver receives a signature score of zero. Unsigned drivers
+// receive the high byte of their signer score.
+func (s Signer) SignatureScore() uint8 {
+	if s.Signed() {
+		return 0
+	}
+	return uint8(s >> 24)
+}
+
+// String returns a string representation of the signer score.
+func (s Signer) String() string {
+	switch s {
+	case LogoPremium:
+		return "LogoPremium"
+	case LogoStandard:
+		return "LogoStandard"
+	case Inbox:
+		return "Inbox"
+	case Unclassified:
+		return "Unclassified"
+	case WHQL:
+		return "WHQL"
+	case Authenticode:
+		return "Authenticode"
+	case Unsigned:
+		return "Unsigned"
+	case W9xSuspect:
+		return "W9xSuspect"
+	case Unknown:
+		return "Unknown"
+	default:
+		return fmt.Sprintf("Signer 0x%08X", uint32(s))
+	}
+}
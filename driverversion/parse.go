@@ -0,0 +1,35 @@
+package driverversion
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse parses a driver version string in the form w.x.y.z, as it appears
+// in the DriverVer directive of an INF file. Missing trailing components
+// are treated as zero.
+func Parse(s string) (Value, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("an empty driver version was provided")
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 4 {
+		return 0, fmt.Errorf("driver version \"%s\" has more than 4 components", s)
+	}
+	var v Value
+	for i := 0; i < 4; i++ {
+		v <<= 16
+		if i >= len(parts) {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(parts[i]), 10, 16)
+		if err != nil {
+			return 0, fmt.Errorf("driver version \"%s\" has an invalid component \"%s\"", s, parts[i])
+		}
+		v |= Value(n)
+	}
+	return v, nil
+}
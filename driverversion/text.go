@@ -0,0 +1,18 @@
+package driverversion
+
+// MarshalText implements encoding.TextMarshaler, encoding v in w.x.y.z
+// form.
+func (v Value) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding a version in
+// w.x.y.z form.
+func (v *Value) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
@@ -0,0 +1,98 @@
+package hwids
+
+// Database holds vendor, device and class names parsed from a file in the
+// pci.ids or usb.ids format.
+type Database struct {
+	Vendors map[uint16]Vendor
+	Classes map[uint8]Class
+}
+
+// Vendor holds the name of a vendor and the devices it produces.
+type Vendor struct {
+	ID      uint16
+	Name    string
+	Devices map[uint16]Device
+}
+
+// Device holds the name of a device and its known subsystems.
+type Device struct {
+	ID         uint16
+	Name       string
+	Subsystems map[Subsystem]string
+}
+
+// Subsystem identifies a PCI subsystem by its vendor and device IDs.
+type Subsystem struct {
+	Vendor uint16
+	Device uint16
+}
+
+// Class holds the name of a device class and its subclasses.
+type Class struct {
+	ID         uint8
+	Name       string
+	SubClasses map[uint8]SubClass
+}
+
+// SubClass holds the name of a device subclass and its programming
+// interfaces or protocols.
+type SubClass struct {
+	ID        uint8
+	Name      string
+	Protocols map[uint8]string
+}
+
+// VendorName returns the name of a vendor. It returns an empty string if
+// the vendor is not known.
+func (db *Database) VendorName(vendor uint16) string {
+	if db == nil {
+		return ""
+	}
+	return db.Vendors[vendor].Name
+}
+
+// DeviceName returns the name of a device. It returns an empty string if
+// the device is not known.
+func (db *Database) DeviceName(vendor, device uint16) string {
+	if db == nil {
+		return ""
+	}
+	return db.Vendors[vendor].Devices[device].Name
+}
+
+// SubsystemName returns the name of a subsystem of a device. It returns an
+// empty string if the subsystem is not known.
+func (db *Database) SubsystemName(vendor, device uint16, subsystem Subsystem) string {
+	if db == nil {
+		return ""
+	}
+	return db.Vendors[vendor].Devices[device].Subsystems[subsystem]
+}
+
+// ClassName returns the name of a device class. It returns an empty string
+// if the class is not known.
+func (db *Database) ClassName(class uint8) string {
+	if db == nil {
+		return ""
+	}
+	return db.Classes[class].Name
+}
+
+// SubClassName returns the name of a device subclass. It returns an empty
+// string if the subclass is not known.
+func (db *Database) SubClassName(class, subClass uint8) string {
+	if db == nil {
+		return ""
+	}
+	return db.Classes[class].SubClasses[subClass].Name
+}
+
+// ProtocolName returns the name of a programming interface or protocol
+// within a device subclass. It returns an empty string if the protocol is
+// not known.
+func (db *Database) ProtocolName(class, subClass, protocol uint8) string {
+	if db == nil {
+		return ""
+	}
+	return db.Classes[class].SubClasses[subClass].Protocols[protocol]
+}
@@ -0,0 +1,14 @@
+// Package hwids resolves PCI and USB vendor, device, subsystem and class
+// names using databases in the pci.ids and usb.ids formats.
+//
+// The formats are maintained by the PCI ID and USB ID projects:
+//
+// https://pci-ids.ucw.cz/
+//
+// http://www.linux-usb.org/usb-ids.html
+//
+// A database can be loaded from a local copy of the files with Load, or
+// taken from the small snapshots embedded in the package with PCI and USB.
+// The embedded snapshots cover common vendors only. A full copy of the
+// files should be loaded when complete coverage is needed.
+package hwids
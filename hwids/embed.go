@@ -0,0 +1,49 @@
+package hwids
+
+import (
+	"bytes"
+	_ "embed" // Required for go:embed
+	"sync"
+)
+
+var (
+	//go:embed pci.ids
+	pciSnapshot []byte
+
+	//go:embed usb.ids
+	usbSnapshot []byte
+)
+
+var (
+	pciOnce sync.Once
+	pciDB   *Database
+
+	usbOnce sync.Once
+	usbDB   *Database
+)
+
+// PCI returns the PCI database snapshot embedded in the package. It is
+// parsed the first time it is requested.
+func PCI() *Database {
+	pciOnce.Do(func() {
+		pciDB = mustParse(pciSnapshot)
+	})
+	return pciDB
+}
+
+// USB returns the USB database snapshot embedded in the package. It is
+// parsed the first time it is requested.
+func USB() *Database {
+	usbOnce.Do(func() {
+		usbDB = mustParse(usbSnapshot)
+	})
+	return usbDB
+}
+
+func mustParse(data []byte) *Database {
+	db, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		panic("hwids: invalid embedded snapshot: " + err.Error())
+	}
+	return db
+}
@@ -0,0 +1,111 @@
+package hwids
+
+import (
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/deviceid"
+)
+
+// Names holds the human readable names resolved for a hardware ID. Names
+// that could not be resolved are empty.
+type Names struct {
+	Vendor    string
+	Device    string
+	Subsystem string
+	Class     string
+	SubClass  string
+	Protocol  string
+}
+
+// Empty returns true if no names were resolved.
+func (n Names) Empty() bool {
+	return n == Names{}
+}
+
+// String returns a short description made from the resolved names, such as
+// "Intel Corporation 82574L Gigabit Network Connection". It falls back to
+// class names when the vendor or device is unknown.
+func (n Names) String() string {
+	var parts []string
+	switch {
+	case n.Subsystem != "":
+		parts = append(parts, n.Vendor, n.Subsystem)
+	case n.Device != "":
+		parts = append(parts, n.Vendor, n.Device)
+	case n.Vendor != "":
+		parts = append(parts, n.Vendor)
+		if class := n.class(); class != "" {
+			parts = append(parts, class)
+		}
+	default:
+		parts = append(parts, n.class())
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+func (n Names) class() string {
+	switch {
+	case n.SubClass != "" && n.Protocol != "":
+		return n.SubClass + " (" + n.Protocol + ")"
+	case n.SubClass != "":
+		return n.SubClass
+	default:
+		return n.Class
+	}
+}
+
+// DescribePCI resolves the names for a decoded PCI hardware ID.
+func (db *Database) DescribePCI(id deviceid.PCI) Names {
+	var n Names
+	if id.HasVendor {
+		n.Vendor = db.VendorName(id.VendorID)
+		if id.HasDevice {
+			n.Device = db.DeviceName(id.VendorID, id.DeviceID)
+			if id.HasSubsystem {
+				n.Subsystem = db.SubsystemName(id.VendorID, id.DeviceID, Subsystem{Vendor: id.SubsystemVendorID, Device: id.SubsystemID})
+			}
+		}
+	}
+	if id.HasClass {
+		n.Class = db.ClassName(id.BaseClass)
+		n.SubClass = db.SubClassName(id.BaseClass, id.SubClass)
+		if id.HasProgIf {
+			n.Protocol = db.ProtocolName(id.BaseClass, id.SubClass, id.ProgIf)
+		}
+	}
+	return n
+}
+
+// DescribeUSB resolves the names for a decoded USB hardware ID.
+func (db *Database) DescribeUSB(id deviceid.USB) Names {
+	var n Names
+	if id.HasVendor {
+		n.Vendor = db.VendorName(id.VendorID)
+		if id.HasProduct {
+			n.Device = db.DeviceName(id.VendorID, id.ProductID)
+		}
+	}
+	if id.HasClass {
+		n.Class = db.ClassName(id.Class)
+		if id.HasSubClass {
+			n.SubClass = db.SubClassName(id.Class, id.SubClass)
+			if id.HasProtocol {
+				n.Protocol = db.ProtocolName(id.Class, id.SubClass, id.Protocol)
+			}
+		}
+	}
+	return n
+}
+
+// DescribeHID resolves the vendor and product names for a decoded HID
+// hardware ID. The database should be a USB database.
+func (db *Database) DescribeHID(id deviceid.HID) Names {
+	var n Names
+	if id.HasVendor {
+		n.Vendor = db.VendorName(id.VendorID)
+		if id.HasProduct {
+			n.Device = db.DeviceName(id.VendorID, id.ProductID)
+		}
+	}
+	return n
+}
@@ -0,0 +1,166 @@
+package hwids
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Load parses the pci.ids or usb.ids file at path.
+func Load(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses a database in the pci.ids or usb.ids format from r.
+//
+// Vendor and class sections are parsed. Other sections, such as the HID
+// usage tables in usb.ids, are skipped.
+func Parse(r io.Reader) (*Database, error) {
+	db := &Database{
+		Vendors: make(map[uint16]Vendor),
+		Classes: make(map[uint8]Class),
+	}
+
+	const (
+		sectionNone = iota
+		sectionVendor
+		sectionClass
+	)
+
+	var (
+		section  = sectionNone
+		vendor   uint16
+		device   uint16
+		class    uint8
+		subClass uint8
+		level1   bool // Whether the current level 1 entry is valid
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \r")
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		depth := 0
+		for depth < len(text) && text[depth] == '\t' {
+			depth++
+		}
+		text = text[depth:]
+
+		switch depth {
+		case 0:
+			level1 = false
+			if rest, ok := cutPrefix(text, "C "); ok {
+				id, name, err := parseEntry(rest, 2)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+				section, class = sectionClass, uint8(id)
+				db.Classes[class] = Class{ID: class, Name: name, SubClasses: make(map[uint8]SubClass)}
+				continue
+			}
+			id, name, err := parseEntry(text, 4)
+			if err != nil {
+				// An unrecognized section, such as the HID usage tables
+				// in usb.ids. Skip it along with its entries.
+				section = sectionNone
+				continue
+			}
+			section, vendor = sectionVendor, uint16(id)
+			db.Vendors[vendor] = Vendor{ID: vendor, Name: name, Devices: make(map[uint16]Device)}
+		case 1:
+			switch section {
+			case sectionVendor:
+				id, name, err := parseEntry(text, 4)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+				device, level1 = uint16(id), true
+				db.Vendors[vendor].Devices[device] = Device{ID: device, Name: name, Subsystems: make(map[Subsystem]string)}
+			case sectionClass:
+				id, name, err := parseEntry(text, 2)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+				subClass, level1 = uint8(id), true
+				db.Classes[class].SubClasses[subClass] = SubClass{ID: subClass, Name: name, Protocols: make(map[uint8]string)}
+			}
+		case 2:
+			if !level1 {
+				continue
+			}
+			switch section {
+			case sectionVendor:
+				// The usb.ids format uses this level for interface names,
+				// which don't have a subsystem vendor and device pair.
+				// Only the pci.ids subsystem form is recorded.
+				subVendor, rest, ok := strings.Cut(text, " ")
+				if !ok {
+					continue
+				}
+				subDevice, name, err := parseEntry(rest, 4)
+				if err != nil {
+					continue
+				}
+				sv, err := parseHex(subVendor, 4)
+				if err != nil {
+					continue
+				}
+				db.Vendors[vendor].Devices[device].Subsystems[Subsystem{Vendor: uint16(sv), Device: uint16(subDevice)}] = name
+			case sectionClass:
+				id, name, err := parseEntry(text, 2)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+				db.Classes[class].SubClasses[subClass].Protocols[uint8(id)] = name
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// parseEntry parses an entry in the form "ID  Name", where ID is a
+// hexadecimal number with the given number of digits.
+func parseEntry(s string, digits int) (id uint64, name string, err error) {
+	if len(s) < digits+1 || s[digits] != ' ' {
+		return 0, "", fmt.Errorf("entry \"%s\" does not begin with a %d digit identifier", s, digits)
+	}
+	id, err = parseHex(s[:digits], digits)
+	if err != nil {
+		return 0, "", err
+	}
+	return id, strings.TrimSpace(s[digits:]), nil
+}
+
+func parseHex(s string, digits int) (uint64, error) {
+	if len(s) != digits {
+		return 0, fmt.Errorf("identifier \"%s\" does not have %d digits", s, digits)
+	}
+	v, err := strconv.ParseUint(s, 16, digits*4)
+	if err != nil {
+		return 0, fmt.Errorf("identifier \"%s\" is not hexadecimal", s)
+	}
+	return v, nil
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
@@ -0,0 +1,14 @@
+// Package indirectstring parses Windows indirect strings.
+//
+// Indirect strings refer to a string stored in another file, usually a
+// localized string in an INF file or a string resource in a module. They
+// take forms such as "@oem12.inf,%intel.devicedesc%;Intel(R) Ethernet
+// Connection" or "@%SystemRoot%\system32\drivers\usbxhci.sys,#1;USB xHCI
+// Compliant Host Controller". The text after the semicolon is a fallback
+// that can be used when the reference cannot be resolved.
+//
+// Indirect strings that refer to INF files can be resolved with
+// inf.Resolver.
+//
+// https://docs.microsoft.com/en-us/windows/win32/api/shlwapi/nf-shlwapi-shloadindirectstring
+package indirectstring
@@ -0,0 +1,88 @@
+package indirectstring
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value is a parsed indirect string.
+type Value struct {
+	File        string // File that holds the string, such as "oem12.inf"
+	Resource    string // String key or resource identifier, such as "%devicedesc%" or "#101"
+	Fallback    string // Text to use when the reference cannot be resolved
+	HasFallback bool
+}
+
+// IsIndirect returns true if s looks like an indirect string.
+func IsIndirect(s string) bool {
+	return strings.HasPrefix(s, "@")
+}
+
+// Parse parses an indirect string in the form
+// "@file,resource[;fallback]".
+func Parse(s string) (Value, error) {
+	if !IsIndirect(s) {
+		return Value{}, fmt.Errorf("\"%s\" is not an indirect string", s)
+	}
+	ref, fallback, hasFallback := strings.Cut(s[1:], ";")
+	file, resource, ok := cutLast(ref, ",")
+	if !ok || file == "" || resource == "" {
+		return Value{}, fmt.Errorf("indirect string \"%s\" does not include a file and resource", s)
+	}
+	return Value{
+		File:        file,
+		Resource:    resource,
+		Fallback:    fallback,
+		HasFallback: hasFallback,
+	}, nil
+}
+
+// Token returns the string key of an INF file reference without its
+// enclosing percent signs, such as "devicedesc" for "%devicedesc%".
+func (v Value) Token() (key string, ok bool) {
+	if len(v.Resource) < 3 || !strings.HasPrefix(v.Resource, "%") || !strings.HasSuffix(v.Resource, "%") {
+		return "", false
+	}
+	return v.Resource[1 : len(v.Resource)-1], true
+}
+
+// ResourceID returns the identifier of a string resource reference, such
+// as 101 for "#101" or "-101".
+func (v Value) ResourceID() (id uint32, ok bool) {
+	s := strings.TrimPrefix(strings.TrimPrefix(v.Resource, "#"), "-")
+	if s == v.Resource {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(n), true
+}
+
+// String returns the indirect string in its original form.
+func (v Value) String() string {
+	s := "@" + v.File + "," + v.Resource
+	if v.HasFallback {
+		s += ";" + v.Fallback
+	}
+	return s
+}
+
+// Fallback returns the fallback text of s if it is an indirect string with
+// a fallback. Otherwise it returns s unchanged.
+func Fallback(s string) string {
+	if v, err := Parse(s); err == nil && v.HasFallback {
+		return v.Fallback
+	}
+	return s
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
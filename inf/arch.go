@@ -0,0 +1,56 @@
+package inf
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Architecture identifies a processor architecture in an INF platform
+// extension, such as the amd64 in NTamd64.
+type Architecture string
+
+// Processor architectures recognized by INF platform extensions.
+const (
+	X86   Architecture = "x86"
+	AMD64 Architecture = "amd64"
+	IA64  Architecture = "ia64"
+	ARM   Architecture = "arm"
+	ARM64 Architecture = "arm64"
+)
+
+// Architectures lists the recognized processor architectures.
+var Architectures = []Architecture{X86, AMD64, IA64, ARM, ARM64}
+
+// ParseArchitecture parses a processor architecture. It is not
+// case-sensitive.
+func ParseArchitecture(s string) (Architecture, error) {
+	for _, arch := range Architectures {
+		if strings.EqualFold(s, string(arch)) {
+			return arch, nil
+		}
+	}
+	return "", fmt.Errorf("unrecognized processor architecture \"%s\"", s)
+}
+
+// DefaultArchitecture returns the processor architecture that the current
+// program was built for. Architectures that Windows does not support are
+// reported as amd64.
+func DefaultArchitecture() Architecture {
+	switch runtime.GOARCH {
+	case "386":
+		return X86
+	case "arm":
+		return ARM
+	case "arm64":
+		return ARM64
+	default:
+		return AMD64
+	}
+}
+
+// Extension returns the platform extension for the architecture, such as
+// NTamd64.
+func (a Architecture) Extension() string {
+	return "NT" + string(a)
+}
@@ -0,0 +1,114 @@
+package inf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Decoration is a TargetOSVersion decoration of a models section, such as
+// NTamd64.10.0...16299. It has the form
+// NT[Architecture][.[OSMajorVersion][.[OSMinorVersion][.[ProductType][.[SuiteMask][.[BuildNumber]]]]]].
+//
+// Each field is only meaningful when its corresponding Has flag is set.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-manufacturer-section
+type Decoration struct {
+	Architecture Architecture // Empty when the decoration does not specify one
+	Major        uint32
+	Minor        uint32
+	ProductType  uint32
+	SuiteMask    uint32
+	Build        uint32
+
+	HasMajor       bool
+	HasMinor       bool
+	HasProductType bool
+	HasSuiteMask   bool
+	HasBuild       bool
+}
+
+// ParseDecoration parses a TargetOSVersion decoration.
+func ParseDecoration(s string) (Decoration, error) {
+	if len(s) < 2 || !strings.EqualFold(s[:2], "NT") {
+		return Decoration{}, fmt.Errorf("decoration \"%s\" does not begin with NT", s)
+	}
+
+	fields := strings.Split(s[2:], ".")
+	if len(fields) > 6 {
+		return Decoration{}, fmt.Errorf("decoration \"%s\" has too many fields", s)
+	}
+
+	var d Decoration
+	if fields[0] != "" {
+		arch, err := ParseArchitecture(fields[0])
+		if err != nil {
+			return Decoration{}, fmt.Errorf("decoration \"%s\": %v", s, err)
+		}
+		d.Architecture = arch
+	}
+
+	targets := []struct {
+		name  string
+		value *uint32
+		has   *bool
+	}{
+		{"OSMajorVersion", &d.Major, &d.HasMajor},
+		{"OSMinorVersion", &d.Minor, &d.HasMinor},
+		{"ProductType", &d.ProductType, &d.HasProductType},
+		{"SuiteMask", &d.SuiteMask, &d.HasSuiteMask},
+		{"BuildNumber", &d.Build, &d.HasBuild},
+	}
+	for i, field := range fields[1:] {
+		if field == "" {
+			continue
+		}
+		v, err := parseNumber(field)
+		if err != nil {
+			return Decoration{}, fmt.Errorf("decoration \"%s\" has an invalid %s \"%s\"", s, targets[i].name, field)
+		}
+		*targets[i].value, *targets[i].has = v, true
+	}
+
+	return d, nil
+}
+
+// TargetArchitecture returns the processor architecture that the decoration
+// applies to. Decorations without an architecture apply to x86.
+func (d Decoration) TargetArchitecture() Architecture {
+	if d.Architecture == "" {
+		return X86
+	}
+	return d.Architecture
+}
+
+// String returns a string representation of the decoration.
+func (d Decoration) String() string {
+	fields := []string{"NT" + string(d.Architecture)}
+	add := func(i int, has bool, value string) {
+		if !has {
+			return
+		}
+		for len(fields) < i {
+			fields = append(fields, "")
+		}
+		fields = append(fields, value)
+	}
+	add(1, d.HasMajor, strconv.FormatUint(uint64(d.Major), 10))
+	add(2, d.HasMinor, strconv.FormatUint(uint64(d.Minor), 10))
+	add(3, d.HasProductType, strconv.FormatUint(uint64(d.ProductType), 10))
+	add(4, d.HasSuiteMask, fmt.Sprintf("0x%08x", d.SuiteMask))
+	add(5, d.HasBuild, strconv.FormatUint(uint64(d.Build), 10))
+	return strings.Join(fields, ".")
+}
+
+// parseNumber parses a decimal number or a hexadecimal number with a 0x
+// prefix.
+func parseNumber(s string) (uint32, error) {
+	if len(s) > 2 && (s[:2] == "0x" || s[:2] == "0X") {
+		v, err := strconv.ParseUint(s[2:], 16, 32)
+		return uint32(v), err
+	}
+	v, err := strconv.ParseUint(s, 10, 32)
+	return uint32(v), err
+}
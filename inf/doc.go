@@ -0,0 +1,11 @@
+// Package inf parses setup information (INF) files without relying on the
+// Windows setup API.
+//
+// INF files are read in ANSI (Windows-1252), UTF-8 or UTF-16LE encodings.
+// Section names and keys are not case-sensitive. Duplicate sections are
+// merged in the order they appear. Values are split into comma-separated
+// fields, quotes are removed and %strkey% tokens are replaced with their
+// definitions from the [Strings] section.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/general-syntax-rules-for-inf-files
+package inf
@@ -0,0 +1,91 @@
+package inf
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// Document is a parsed INF file.
+type Document struct {
+	Encoding Encoding
+	Sections []*Section // In order of first appearance
+
+	index   map[string]*Section // Lower case section name to section
+	strings map[string]string   // Lower case string key to value
+}
+
+// Load reads and parses the INF file at path.
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBytes(data)
+}
+
+// Parse reads and parses an INF file from r.
+func Parse(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBytes(data)
+}
+
+// ParseBytes parses the contents of an INF file. The encoding is detected
+// from the byte order mark.
+func ParseBytes(data []byte) (*Document, error) {
+	text, encoding, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := ParseString(text)
+	if err != nil {
+		return nil, err
+	}
+	doc.Encoding = encoding
+	return doc, nil
+}
+
+// Section returns the section with the given name. The name is not
+// case-sensitive. It returns nil if the section does not exist.
+func (d *Document) Section(name string) *Section {
+	return d.index[strings.ToLower(name)]
+}
+
+// HasSection returns true if the document contains a section with the
+// given name.
+func (d *Document) HasSection(name string) bool {
+	return d.Section(name) != nil
+}
+
+// Value returns the first value of the first entry with the given key in
+// the given section. It returns an empty string if the section, key or
+// value does not exist.
+func (d *Document) Value(section, key string) string {
+	return d.Section(section).Value(key)
+}
+
+// Lookup returns the definition of a string key from the [Strings]
+// section. The key is not case-sensitive and should not include the
+// enclosing percent signs.
+func (d *Document) Lookup(key string) (value string, ok bool) {
+	value, ok = d.strings[strings.ToLower(key)]
+	return
+}
+
+// Expand removes quotes from s and replaces any %strkey% tokens it
+// contains with their definitions from the [Strings] section. Undefined
+// tokens are left in place.
+func (d *Document) Expand(s string) string {
+	value, _ := expand(s, d.strings)
+	return value
+}
+
+// Unresolved returns the string keys referenced by s that are not defined
+// in the [Strings] section.
+func (d *Document) Unresolved(s string) []string {
+	_, missing := expand(s, d.strings)
+	return missing
+}
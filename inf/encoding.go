@@ -0,0 +1,84 @@
+package inf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Encoding identifies the character encoding of an INF file.
+type Encoding int
+
+// INF file encodings.
+const (
+	ANSI    Encoding = 0 // Windows-1252, used when no byte order mark is present
+	UTF8    Encoding = 1 // UTF-8 with a byte order mark
+	UTF16LE Encoding = 2 // UTF-16 little endian with a byte order mark
+)
+
+// String returns a string representation of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case ANSI:
+		return "ANSI"
+	case UTF8:
+		return "UTF-8"
+	case UTF16LE:
+		return "UTF-16LE"
+	default:
+		return fmt.Sprintf("Unknown Encoding %d", e)
+	}
+}
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+)
+
+// Decode detects the encoding of INF file data from its byte order mark
+// and returns its contents as a string.
+func Decode(data []byte) (text string, encoding Encoding, err error) {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return string(data[len(bomUTF8):]), UTF8, nil
+	case bytes.HasPrefix(data, bomUTF16LE):
+		data = data[len(bomUTF16LE):]
+		if len(data)%2 != 0 {
+			return "", UTF16LE, errors.New("UTF-16LE data has an odd number of bytes")
+		}
+		units := make([]uint16, len(data)/2)
+		for i := range units {
+			units[i] = uint16(data[i*2]) | uint16(data[i*2+1])<<8
+		}
+		return string(utf16.Decode(units)), UTF16LE, nil
+	default:
+		return decodeWindows1252(data), ANSI, nil
+	}
+}
+
+// windows1252 maps the bytes 0x80 through 0x9F to their Unicode code
+// points. Undefined bytes map to the Unicode replacement character.
+var windows1252 = [32]rune{
+	'€', '�', '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', '�', 'Ž', '�',
+	'�', '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', '�', 'ž', 'Ÿ',
+}
+
+func decodeWindows1252(data []byte) string {
+	var b strings.Builder
+	b.Grow(len(data))
+	for _, c := range data {
+		switch {
+		case c < 0x80:
+			b.WriteByte(c)
+		case c < 0xA0:
+			b.WriteRune(windows1252[c-0x80])
+		default:
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
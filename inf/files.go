@@ -0,0 +1,92 @@
+package inf
+
+import "strings"
+
+// CopyFile is a file that an install section copies to the target system.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-copyfiles-directive
+type CopyFile struct {
+	Destination string // Destination file name
+	Source      string // Source file name, which matches Destination unless renamed
+	Flags       uint32 // COPYFLG flags
+	Section     string // File-list section, or empty for the CopyFiles=@file form
+	Line        int
+}
+
+// CopyFiles returns the files copied by the CopyFiles directives of an
+// install section, in the order they appear. File-list sections that do
+// not exist are skipped.
+func (d *Document) CopyFiles(install *Section) []CopyFile {
+	var files []CopyFile
+	for _, directive := range install.Find("CopyFiles") {
+		for _, value := range directive.Values {
+			if value == "" {
+				continue
+			}
+			if name := strings.TrimPrefix(value, "@"); name != value {
+				files = append(files, CopyFile{
+					Destination: name,
+					Source:      name,
+					Line:        directive.Line,
+				})
+				continue
+			}
+			list := d.Section(value)
+			if list == nil {
+				continue
+			}
+			for _, entry := range list.Entries {
+				// destination-file-name[,source-file-name][,unused][,flag]
+				file := CopyFile{
+					Destination: entry.Value(0),
+					Source:      entry.Value(1),
+					Section:     list.Name,
+					Line:        entry.Line,
+				}
+				if file.Source == "" {
+					file.Source = file.Destination
+				}
+				if flags, err := parseNumber(entry.Value(3)); err == nil {
+					file.Flags = flags
+				}
+				if file.Destination != "" {
+					files = append(files, file)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// SourceFile is an entry in a [SourceDisksFiles] section.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-sourcedisksfiles-section
+type SourceFile struct {
+	Name    string
+	DiskID  string
+	Subdir  string
+	Section string
+	Line    int
+}
+
+// SourceFile looks up a file in the [SourceDisksFiles.architecture] and
+// [SourceDisksFiles] sections, in that order. The file name is not
+// case-sensitive.
+func (d *Document) SourceFile(name string, arch Architecture) (file SourceFile, ok bool) {
+	for _, sectionName := range []string{"SourceDisksFiles." + string(arch), "SourceDisksFiles"} {
+		section := d.Section(sectionName)
+		if section == nil {
+			continue
+		}
+		if entry, found := section.Entry(name); found {
+			return SourceFile{
+				Name:    entry.Key,
+				DiskID:  entry.Value(0),
+				Subdir:  entry.Value(1),
+				Section: section.Name,
+				Line:    entry.Line,
+			}, true
+		}
+	}
+	return SourceFile{}, false
+}
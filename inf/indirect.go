@@ -0,0 +1,160 @@
+package inf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gentlemanautomaton/windevice/indirectstring"
+)
+
+// LookupIndirect resolves an indirect string against the document, using
+// the localized strings for the given language when they are present. The
+// file named by v is not checked against the document.
+func (d *Document) LookupIndirect(v indirectstring.Value, lang Language) (string, error) {
+	key, ok := v.Token()
+	if !ok {
+		return "", fmt.Errorf("\"%s\" is not an INF string key", v.Resource)
+	}
+	value, ok := d.LookupLanguage(key, lang)
+	if !ok {
+		return "", fmt.Errorf("the \"%s\" string key is not defined in %s", key, v.File)
+	}
+	return value, nil
+}
+
+// Resolver resolves indirect strings that refer to INF files. It caches
+// the INF files that it reads. It is safe for concurrent use.
+//
+// String resources in modules, such as "@driver.sys,#101", are not
+// resolved.
+type Resolver struct {
+	lang Language
+	dirs []string
+
+	mutex sync.Mutex
+	docs  map[string]*Document
+	errs  map[string]error
+}
+
+// NewResolver returns a resolver that looks up strings for the given
+// language. INF files named without a directory are searched for in
+// dirs. If no directories are provided, the INF directory of the running
+// system is used.
+func NewResolver(lang Language, dirs ...string) *Resolver {
+	if len(dirs) == 0 {
+		dirs = []string{filepath.Join(os.Getenv("SystemRoot"), "INF")}
+	}
+	return &Resolver{
+		lang: lang,
+		dirs: dirs,
+		docs: make(map[string]*Document),
+		errs: make(map[string]error),
+	}
+}
+
+// Resolve returns the text that s refers to. Strings that are not
+// indirect are returned unchanged.
+//
+// If the reference cannot be resolved, Resolve returns the fallback text
+// of s, or s itself when it has no fallback, along with an error that
+// describes the failure.
+func (r *Resolver) Resolve(s string) (string, error) {
+	if !indirectstring.IsIndirect(s) {
+		return s, nil
+	}
+	v, err := indirectstring.Parse(s)
+	if err != nil {
+		return s, err
+	}
+	fallback := s
+	if v.HasFallback {
+		fallback = v.Fallback
+	}
+
+	if _, ok := v.ResourceID(); ok {
+		return fallback, fmt.Errorf("unable to resolve \"%s\": string resources are not supported", s)
+	}
+	if !strings.EqualFold(filepath.Ext(v.File), ".inf") {
+		return fallback, fmt.Errorf("unable to resolve \"%s\": \"%s\" is not an INF file", s, v.File)
+	}
+
+	doc, err := r.load(v.File)
+	if err != nil {
+		return fallback, fmt.Errorf("unable to resolve \"%s\": %v", s, err)
+	}
+	value, err := doc.LookupIndirect(v, r.lang)
+	if err != nil {
+		return fallback, fmt.Errorf("unable to resolve \"%s\": %v", s, err)
+	}
+	return value, nil
+}
+
+// load returns the parsed INF file with the given name.
+func (r *Resolver) load(name string) (*Document, error) {
+	key := strings.ToLower(name)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if doc, ok := r.docs[key]; ok {
+		return doc, nil
+	}
+	if err, ok := r.errs[key]; ok {
+		return nil, err
+	}
+
+	doc, err := r.read(expandEnv(name))
+	if err != nil {
+		r.errs[key] = err
+		return nil, err
+	}
+	r.docs[key] = doc
+	return doc, nil
+}
+
+func (r *Resolver) read(name string) (*Document, error) {
+	if strings.ContainsAny(name, `\/`) {
+		return Load(filepath.FromSlash(name))
+	}
+	for _, dir := range r.dirs {
+		doc, err := Load(filepath.Join(dir, name))
+		if err == nil {
+			return doc, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("\"%s\" was not found", name)
+}
+
+// expandEnv replaces %variable% references in s with the values of the
+// corresponding environment variables. Undefined variables are left in
+// place.
+func expandEnv(s string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "%")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start+1:], "%")
+		if end < 0 {
+			break
+		}
+		end += start + 1
+		name := s[start+1 : end]
+		if value, ok := os.LookupEnv(name); ok && name != "" {
+			b.WriteString(s[:start])
+			b.WriteString(value)
+		} else {
+			b.WriteString(s[:end+1])
+		}
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package inf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Language is a Windows language identifier (LANGID), as used in the
+// names of localized [Strings.LanguageID] sections.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/creating-international-inf-files
+type Language uint16
+
+// Neutral is the language-neutral language identifier. It selects the
+// undecorated [Strings] section.
+const Neutral Language = 0x0000 // LANG_NEUTRAL
+
+// languageTags maps common locale names to language identifiers.
+var languageTags = map[string]Language{
+	"ar-sa": 0x0401, "bg-bg": 0x0402, "ca-es": 0x0403, "zh-tw": 0x0404,
+	"cs-cz": 0x0405, "da-dk": 0x0406, "de-de": 0x0407, "el-gr": 0x0408,
+	"en-us": 0x0409, "fi-fi": 0x040b, "fr-fr": 0x040c, "he-il": 0x040d,
+	"hu-hu": 0x040e, "it-it": 0x0410, "ja-jp": 0x0411, "ko-kr": 0x0412,
+	"nl-nl": 0x0413, "nb-no": 0x0414, "pl-pl": 0x0415, "pt-br": 0x0416,
+	"ro-ro": 0x0418, "ru-ru": 0x0419, "hr-hr": 0x041a, "sk-sk": 0x041b,
+	"sv-se": 0x041d, "th-th": 0x041e, "tr-tr": 0x041f, "uk-ua": 0x0422,
+	"sl-si": 0x0424, "et-ee": 0x0425, "lv-lv": 0x0426, "lt-lt": 0x0427,
+	"vi-vn": 0x042a, "zh-cn": 0x0804, "de-ch": 0x0807, "en-gb": 0x0809,
+	"es-mx": 0x080a, "fr-be": 0x080c, "nl-be": 0x0813, "pt-pt": 0x0816,
+	"sr-latn-rs": 0x241a, "zh-hk": 0x0c04, "de-at": 0x0c07, "en-au": 0x0c09,
+	"es-es": 0x0c0a, "fr-ca": 0x0c0c, "en-ca": 0x1009, "fr-ch": 0x100c,
+}
+
+// ParseLanguage parses a language identifier in hexadecimal form, such as
+// "0407" or "0x0407", or a locale name such as "de-DE". A language name
+// without a region, such as "de", returns the primary language with a
+// neutral sublanguage.
+func ParseLanguage(s string) (Language, error) {
+	s = strings.TrimSpace(s)
+	if lang, ok := languageTags[strings.ToLower(strings.ReplaceAll(s, "_", "-"))]; ok {
+		return lang, nil
+	}
+	for _, lang := range languageTags {
+		if prefix, _, _ := strings.Cut(lang.tag(), "-"); strings.EqualFold(prefix, s) {
+			return lang.Primary(), nil
+		}
+	}
+	hex := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if len(hex) == 4 {
+		if v, err := strconv.ParseUint(hex, 16, 16); err == nil {
+			return Language(v), nil
+		}
+	}
+	return 0, fmt.Errorf("\"%s\" is not a language identifier or locale name", s)
+}
+
+// Primary returns the language identifier of the primary language of l,
+// with a neutral sublanguage.
+func (l Language) Primary() Language {
+	return l & 0x03ff
+}
+
+// String returns the language identifier as four hexadecimal digits, in
+// the form used by [Strings.LanguageID] section names.
+func (l Language) String() string {
+	return fmt.Sprintf("%04x", uint16(l))
+}
+
+// tag returns the locale name of l, or an empty string if it is not known.
+func (l Language) tag() string {
+	for tag, lang := range languageTags {
+		if lang == l {
+			return tag
+		}
+	}
+	return ""
+}
+
+// Languages returns the languages of the localized [Strings.LanguageID]
+// sections in the document, in the order they appear.
+func (d *Document) Languages() []Language {
+	var languages []Language
+	for _, section := range d.Sections {
+		if !isStringsSection(section.Name) || len(section.Name) <= 8 {
+			continue
+		}
+		if v, err := strconv.ParseUint(section.Name[8:], 16, 16); err == nil {
+			languages = append(languages, Language(v))
+		}
+	}
+	return languages
+}
+
+// LookupLanguage returns the definition of a string key for the given
+// language. It searches the [Strings.LanguageID] section for the exact
+// language, then one for the primary language with a neutral sublanguage,
+// then any section with the same primary language and finally the
+// [Strings] section. The key is not case-sensitive and should not include
+// the enclosing percent signs.
+func (d *Document) LookupLanguage(key string, lang Language) (value string, ok bool) {
+	if lang != Neutral {
+		candidates := []Language{lang, lang.Primary()}
+		for _, other := range d.Languages() {
+			if other.Primary() == lang.Primary() {
+				candidates = append(candidates, other)
+			}
+		}
+		for _, candidate := range candidates {
+			if entry, found := d.Section("Strings." + candidate.String()).Entry(key); found {
+				return entry.Value(0), true
+			}
+		}
+	}
+	return d.Lookup(key)
+}
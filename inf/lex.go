@@ -0,0 +1,131 @@
+package inf
+
+import "strings"
+
+// splitLines splits text into lines, accepting both CRLF and LF line
+// endings.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// stripComment removes a trailing comment from line. Semicolons within
+// quotes do not begin a comment.
+func stripComment(line string) string {
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				return strings.TrimRight(line[:i], " \t")
+			}
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+// cutUnquoted slices s around the first instance of sep that is not
+// within quotes.
+func cutUnquoted(s string, sep byte) (before, after string, found bool) {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return s, "", false
+}
+
+// splitFields splits s into comma-separated fields. Commas within quotes
+// do not separate fields. Each field is trimmed of surrounding whitespace.
+func splitFields(s string) []string {
+	var fields []string
+	for {
+		field, rest, found := cutUnquoted(s, ',')
+		fields = append(fields, strings.TrimSpace(field))
+		if !found {
+			return fields
+		}
+		s = rest
+	}
+}
+
+// unquote removes quotes from s. A pair of quotes within a quoted string
+// represents a single literal quote.
+func unquote(s string) string {
+	if !strings.Contains(s, "\"") {
+		return s
+	}
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '"' {
+			b.WriteByte(c)
+			continue
+		}
+		if quoted && i+1 < len(s) && s[i+1] == '"' {
+			b.WriteByte('"')
+			i++
+			continue
+		}
+		quoted = !quoted
+	}
+	return b.String()
+}
+
+// expand removes quotes from s and replaces %strkey% tokens with their
+// definitions in strs, which must have lower case keys. A pair of percent
+// signs represents a single literal percent sign. Tokens without a
+// definition are left in place and returned in missing.
+func expand(s string, strs map[string]string) (value string, missing []string) {
+	s = unquote(s)
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(s, '%')
+		if start < 0 {
+			b.WriteString(s)
+			break
+		}
+		end := strings.IndexByte(s[start+1:], '%')
+		if end < 0 {
+			b.WriteString(s)
+			break
+		}
+		end += start + 1
+		b.WriteString(s[:start])
+		token := s[start+1 : end]
+		switch {
+		case token == "":
+			b.WriteByte('%')
+		default:
+			if def, ok := strs[strings.ToLower(token)]; ok {
+				b.WriteString(def)
+			} else {
+				b.WriteString(s[start : end+1])
+				missing = append(missing, token)
+			}
+		}
+		s = s[end+1:]
+	}
+	return b.String(), missing
+}
+
+// isStringsSection returns true if name is [Strings] or a localized
+// [Strings.LanguageID] section.
+func isStringsSection(name string) bool {
+	return strings.EqualFold(name, "Strings") || (len(name) > 8 && strings.EqualFold(name[:8], "Strings."))
+}
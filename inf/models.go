@@ -0,0 +1,182 @@
+package inf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/deviceid"
+)
+
+// Manufacturer is an entry in the [Manufacturer] section of an INF file.
+type Manufacturer struct {
+	Name        string   // Expanded manufacturer name
+	Section     string   // Undecorated models section name
+	Decorations []string // TargetOSVersion decorations, as they appear in the file
+	Line        int
+}
+
+// Manufacturers returns the entries in the [Manufacturer] section of the
+// document. It returns nil if the section does not exist.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-manufacturer-section
+func (d *Document) Manufacturers() []Manufacturer {
+	section := d.Section("Manufacturer")
+	if section == nil {
+		return nil
+	}
+	var manufacturers []Manufacturer
+	for _, entry := range section.Entries {
+		m := Manufacturer{Line: entry.Line}
+		if entry.RawKey != "" {
+			// %strkey%=models-section-name[,TargetOSVersion]...
+			m.Name = entry.Key
+			m.Section = entry.Value(0)
+			if len(entry.Values) > 1 {
+				m.Decorations = entry.Values[1:]
+			}
+		} else {
+			// %strkey% alone names a models section after the token
+			m.Name = entry.Value(0)
+			m.Section = strings.Trim(unquote(entry.RawValues[0]), "%")
+		}
+		manufacturers = append(manufacturers, m)
+	}
+	return manufacturers
+}
+
+// ModelsSection returns the name of the models section that applies to
+// the given platform. It returns false if none of the manufacturer's
+// models sections apply to the platform. See Select for details.
+func (m Manufacturer) ModelsSection(p Platform) (name string, ok bool) {
+	sel := m.Select(p)
+	return sel.Section, sel.Selected()
+}
+
+// Architecture returns the processor architecture that one of the
+// manufacturer's models sections applies to. Undecorated sections and
+// sections without an architecture in their decoration apply to x86.
+func (m Manufacturer) Architecture(section string) (Architecture, error) {
+	if len(section) < len(m.Section) || !strings.EqualFold(section[:len(m.Section)], m.Section) {
+		return "", fmt.Errorf("models section [%s] does not belong to manufacturer \"%s\"", section, m.Name)
+	}
+	decoration := strings.TrimPrefix(section[len(m.Section):], ".")
+	if decoration == "" {
+		return X86, nil
+	}
+	parsed, err := ParseDecoration(decoration)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Architecture == "" {
+		return X86, nil
+	}
+	return parsed.Architecture, nil
+}
+
+// Model is an entry in a models section of an INF file. It describes a
+// device that the driver package can be installed on.
+type Model struct {
+	Manufacturer   string
+	Section        string // Decorated models section name
+	Description    string
+	InstallSection string // Undecorated install section name
+	HardwareID     deviceid.Hardware
+	CompatibleIDs  []deviceid.Compatible
+	Line           int
+}
+
+// ModelsSections returns the names of every models section that the
+// manufacturer refers to. The undecorated section is listed last, and only
+// when the manufacturer has no decorations or the section is present in the
+// document.
+func (d *Document) ModelsSections(m Manufacturer) []string {
+	names := make([]string, 0, len(m.Decorations)+1)
+	for _, decoration := range m.Decorations {
+		names = append(names, m.Section+"."+decoration)
+	}
+	if len(m.Decorations) == 0 || d.HasSection(m.Section) {
+		names = append(names, m.Section)
+	}
+	return names
+}
+
+// Models returns every models entry that the document offers on the given
+// platform, in the order they appear.
+func (d *Document) Models(p Platform) []Model {
+	var models []Model
+	for _, m := range d.Manufacturers() {
+		if name, ok := m.ModelsSection(p); ok {
+			models = append(models, d.ModelsIn(m, name)...)
+		}
+	}
+	return models
+}
+
+// ModelsIn returns the entries in the named models section of a
+// manufacturer. It returns nil if the section does not exist.
+func (d *Document) ModelsIn(m Manufacturer, name string) []Model {
+	section := d.Section(name)
+	if section == nil {
+		return nil
+	}
+	models := make([]Model, 0, len(section.Entries))
+	for _, entry := range section.Entries {
+		model := Model{
+			Manufacturer:   m.Name,
+			Section:        section.Name,
+			Description:    entry.Key,
+			InstallSection: entry.Value(0),
+			HardwareID:     deviceid.Hardware(entry.Value(1)),
+			Line:           entry.Line,
+		}
+		if len(entry.Values) > 2 {
+			for _, id := range entry.Values[2:] {
+				if id != "" {
+					model.CompatibleIDs = append(model.CompatibleIDs, deviceid.Compatible(id))
+				}
+			}
+		}
+		models = append(models, model)
+	}
+	return models
+}
+
+// FindModels returns the models entries that the document offers on the
+// given platform for a hardware or compatible ID. The comparison is not
+// case-sensitive.
+func (d *Document) FindModels(p Platform, id string) []Model {
+	var matched []Model
+	for _, model := range d.Models(p) {
+		if model.Matches(id) {
+			matched = append(matched, model)
+		}
+	}
+	return matched
+}
+
+// Matches returns true if id is the hardware ID or one of the compatible
+// IDs of the model. The comparison is not case-sensitive.
+func (m Model) Matches(id string) bool {
+	if strings.EqualFold(string(m.HardwareID), id) {
+		return true
+	}
+	for _, compatible := range m.CompatibleIDs {
+		if strings.EqualFold(string(compatible), id) {
+			return true
+		}
+	}
+	return false
+}
+
+// InstallSection returns the install section with the given name that
+// applies to the processor architecture. Windows looks for a section with
+// a .NTarchitecture extension, then a .NT extension, then no extension.
+// It returns nil if none of them exist.
+func (d *Document) InstallSection(name string, arch Architecture) *Section {
+	for _, candidate := range []string{name + "." + arch.Extension(), name + ".NT", name} {
+		if section := d.Section(candidate); section != nil {
+			return section
+		}
+	}
+	return nil
+}
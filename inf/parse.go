@@ -0,0 +1,105 @@
+package inf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseString parses the decoded text of an INF file.
+func ParseString(text string) (*Document, error) {
+	doc := &Document{
+		index:   make(map[string]*Section),
+		strings: make(map[string]string),
+	}
+
+	var (
+		section *Section
+		lines   = splitLines(text)
+	)
+
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := stripComment(lines[i])
+
+		// Join continuation lines
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + stripComment(lines[i])
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Section headers
+		if line[0] == '[' {
+			end := strings.IndexByte(line, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: section header \"%s\" is not terminated", lineNumber, line)
+			}
+			name := strings.TrimSpace(line[1:end])
+			key := strings.ToLower(name)
+			if existing, ok := doc.index[key]; ok {
+				section = existing
+			} else {
+				section = &Section{Name: name, Line: lineNumber}
+				doc.index[key] = section
+				doc.Sections = append(doc.Sections, section)
+			}
+			continue
+		}
+
+		// Lines that appear before the first section are ignored
+		if section == nil {
+			continue
+		}
+
+		rawKey, rest, hasKey := cutUnquoted(line, '=')
+		if !hasKey {
+			rawKey, rest = "", line
+		}
+		entry := Entry{
+			Line:   lineNumber,
+			RawKey: strings.TrimSpace(rawKey),
+		}
+
+		if isStringsSection(section.Name) {
+			// String definitions are not split into fields
+			rest = strings.TrimSpace(rest)
+			entry.Key = entry.RawKey
+			entry.RawValues = []string{rest}
+			entry.Values = []string{unquote(rest)}
+			if strings.EqualFold(section.Name, "Strings") && entry.Key != "" {
+				key := strings.ToLower(entry.Key)
+				if _, exists := doc.strings[key]; !exists {
+					doc.strings[key] = entry.Values[0]
+				}
+			}
+			section.Entries = append(section.Entries, entry)
+			continue
+		}
+
+		if rest = strings.TrimSpace(rest); rest != "" {
+			entry.RawValues = splitFields(rest)
+		}
+		section.Entries = append(section.Entries, entry)
+	}
+
+	// Expand string tokens now that all [Strings] sections have been read
+	for _, section := range doc.Sections {
+		if isStringsSection(section.Name) {
+			continue
+		}
+		for i := range section.Entries {
+			entry := &section.Entries[i]
+			entry.Key, _ = expand(entry.RawKey, doc.strings)
+			if len(entry.RawValues) > 0 {
+				entry.Values = make([]string, len(entry.RawValues))
+				for v, raw := range entry.RawValues {
+					entry.Values[v], _ = expand(raw, doc.strings)
+				}
+			}
+		}
+	}
+	return doc, nil
+}
@@ -0,0 +1,158 @@
+package inf
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Product types used by the ProductType field of TargetOSVersion
+// decorations.
+//
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-osversioninfoexw
+const (
+	ProductWorkstation      = 0x0000001 // VER_NT_WORKSTATION
+	ProductDomainController = 0x0000002 // VER_NT_DOMAIN_CONTROLLER
+	ProductServer           = 0x0000003 // VER_NT_SERVER
+)
+
+// Suite flags used by the SuiteMask field of TargetOSVersion decorations.
+//
+// https://docs.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-osversioninfoexw
+const (
+	SuiteSmallBusiness           = 0x00000001 // VER_SUITE_SMALLBUSINESS
+	SuiteEnterprise              = 0x00000002 // VER_SUITE_ENTERPRISE
+	SuiteBackOffice              = 0x00000004 // VER_SUITE_BACKOFFICE
+	SuiteCommunications          = 0x00000008 // VER_SUITE_COMMUNICATIONS
+	SuiteTerminal                = 0x00000010 // VER_SUITE_TERMINAL
+	SuiteSmallBusinessRestricted = 0x00000020 // VER_SUITE_SMALLBUSINESS_RESTRICTED
+	SuiteEmbeddedNT              = 0x00000040 // VER_SUITE_EMBEDDEDNT
+	SuiteDataCenter              = 0x00000080 // VER_SUITE_DATACENTER
+	SuiteSingleUserTS            = 0x00000100 // VER_SUITE_SINGLEUSERTS
+	SuitePersonal                = 0x00000200 // VER_SUITE_PERSONAL
+	SuiteBlade                   = 0x00000400 // VER_SUITE_BLADE
+	SuiteEmbeddedRestricted      = 0x00000800 // VER_SUITE_EMBEDDED_RESTRICTED
+	SuiteSecurityAppliance       = 0x00001000 // VER_SUITE_SECURITY_APPLIANCE
+	SuiteStorageServer           = 0x00002000 // VER_SUITE_STORAGE_SERVER
+	SuiteComputeServer           = 0x00004000 // VER_SUITE_COMPUTE_SERVER
+	SuiteWHServer                = 0x00008000 // VER_SUITE_WH_SERVER
+)
+
+// Platform describes the version of Windows that an INF file is evaluated
+// against.
+//
+// Decorations that specify a product type only match platforms with the
+// same ProductType, so it should be set when such decorations are
+// expected.
+type Platform struct {
+	Architecture Architecture
+	Major        uint32 // Major version, such as 10
+	Minor        uint32 // Minor version, such as 0
+	ProductType  uint32 // One of the Product constants
+	SuiteMask    uint32 // A combination of Suite flags
+	Build        uint32 // Build number, such as 19041
+}
+
+// ParsePlatform returns the platform for a processor architecture, an OS
+// version in major[.minor[.build]] form, such as 10.0.19041, and one of the
+// Product constants.
+func ParsePlatform(arch, version string, productType uint32) (Platform, error) {
+	a, err := ParseArchitecture(arch)
+	if err != nil {
+		return Platform{}, err
+	}
+	p := Platform{Architecture: a, ProductType: productType}
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return Platform{}, fmt.Errorf("invalid OS version \"%s\"", version)
+	}
+	fields := []*uint32{&p.Major, &p.Minor, &p.Build}
+	for i, part := range parts {
+		v, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return Platform{}, fmt.Errorf("invalid OS version \"%s\"", version)
+		}
+		*fields[i] = uint32(v)
+	}
+	return p, nil
+}
+
+// String returns a string representation of the platform in the form of
+// a fully specified decoration.
+func (p Platform) String() string {
+	return fmt.Sprintf("NT%s.%d.%d.%d.0x%08x.%d", p.Architecture, p.Major, p.Minor, p.ProductType, p.SuiteMask, p.Build)
+}
+
+// Evaluate returns nil if the decoration applies to the platform.
+// Otherwise it returns an error that describes why the decoration was
+// rejected.
+//
+// A decoration without an architecture only applies to x86 platforms.
+// Version and build fields are minimums. The product type must match
+// exactly, and every suite flag in the decoration must be present on the
+// platform.
+func (d Decoration) Evaluate(p Platform) error {
+	if arch := d.TargetArchitecture(); arch != p.Architecture {
+		if d.Architecture == "" {
+			return fmt.Errorf("decoration has no architecture and only applies to %s, not %s", X86, p.Architecture)
+		}
+		return fmt.Errorf("decoration targets %s, not %s", d.Architecture, p.Architecture)
+	}
+	if d.HasMajor && compareVersion(p.Major, p.Minor, d.Major, d.Minor) < 0 {
+		return fmt.Errorf("decoration requires OS version %d.%d or later, platform is %d.%d", d.Major, d.Minor, p.Major, p.Minor)
+	}
+	if d.HasProductType && d.ProductType != p.ProductType {
+		return fmt.Errorf("decoration requires product type %d, platform is %d", d.ProductType, p.ProductType)
+	}
+	if d.HasSuiteMask && d.SuiteMask&p.SuiteMask != d.SuiteMask {
+		return fmt.Errorf("decoration requires suite mask 0x%08x, platform has 0x%08x", d.SuiteMask, p.SuiteMask)
+	}
+	if d.HasBuild && p.Build < d.Build {
+		return fmt.Errorf("decoration requires build %d or later, platform is build %d", d.Build, p.Build)
+	}
+	return nil
+}
+
+// Matches returns true if the decoration applies to the platform.
+func (d Decoration) Matches(p Platform) bool {
+	return d.Evaluate(p) == nil
+}
+
+// compareVersion compares two major.minor version pairs.
+func compareVersion(major1, minor1, major2, minor2 uint32) int {
+	switch {
+	case major1 < major2:
+		return -1
+	case major1 > major2:
+		return 1
+	case minor1 < minor2:
+		return -1
+	case minor1 > minor2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// moreSpecific returns true if a is a better match for a platform than b,
+// assuming that both match it.
+//
+// Higher OS versions are preferred, followed by higher build numbers.
+// Remaining ties are broken in favor of decorations that specify an
+// architecture, a product type and more suite flags.
+func moreSpecific(a, b Decoration) bool {
+	if c := compareVersion(a.Major, a.Minor, b.Major, b.Minor); c != 0 {
+		return c > 0
+	}
+	if a.Build != b.Build {
+		return a.Build > b.Build
+	}
+	if (a.Architecture != "") != (b.Architecture != "") {
+		return a.Architecture != ""
+	}
+	if a.HasProductType != b.HasProductType {
+		return a.HasProductType
+	}
+	return bits.OnesCount32(a.SuiteMask) > bits.OnesCount32(b.SuiteMask)
+}
@@ -0,0 +1,55 @@
+package inf
+
+import "fmt"
+
+// Preview describes the changes that a DDInstall section makes to a
+// system when a driver is installed.
+type Preview struct {
+	Section  string          // The install section, including its decoration
+	Files    []CopyFile      // Files copied by the install and co-installer sections
+	Software []RegistryEntry // Entries relative to the driver's software key
+	Hardware []RegistryEntry // Entries from the .HW section, relative to the device's hardware key
+	Services []Service       // Services from the .Services section
+	Includes []string        // INF files named by Include directives
+	Needs    []string        // Sections of included INF files, which are not previewed
+}
+
+// Preview returns the changes made by the named DDInstall section on the
+// given architecture. The name should not be decorated; the most specific
+// section that exists is used, along with its .CoInstallers, .HW and
+// .Services sections.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-ddinstall-section
+func (d *Document) Preview(name string, arch Architecture) (Preview, error) {
+	install := d.InstallSection(name, arch)
+	if install == nil {
+		return Preview{}, fmt.Errorf("the \"%s\" install section does not exist", name)
+	}
+
+	preview := Preview{Section: install.Name}
+	for _, section := range []*Section{install, d.Section(install.Name + ".CoInstallers")} {
+		preview.Files = append(preview.Files, d.CopyFiles(section)...)
+		preview.Software = append(preview.Software, d.Registry(section)...)
+	}
+	preview.Hardware = d.Registry(d.Section(install.Name + ".HW"))
+	preview.Services = d.Services(d.Section(install.Name + ".Services"))
+
+	for _, entry := range install.Find("Include") {
+		preview.Includes = append(preview.Includes, nonEmpty(entry.Values)...)
+	}
+	for _, entry := range install.Find("Needs") {
+		preview.Needs = append(preview.Needs, nonEmpty(entry.Values)...)
+	}
+
+	return preview, nil
+}
+
+func nonEmpty(values []string) []string {
+	var out []string
+	for _, value := range values {
+		if value != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
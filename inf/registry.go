@@ -0,0 +1,469 @@
+package inf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RootKey identifies the registry root of an AddReg, DelReg or BitReg
+// entry.
+type RootKey string
+
+// Registry root keys.
+const (
+	HKCR RootKey = "HKCR" // HKEY_CLASSES_ROOT
+	HKCU RootKey = "HKCU" // HKEY_CURRENT_USER
+	HKLM RootKey = "HKLM" // HKEY_LOCAL_MACHINE
+	HKU  RootKey = "HKU"  // HKEY_USERS
+	HKR  RootKey = "HKR"  // The key associated with the referencing section
+)
+
+// ParseRootKey parses a registry root abbreviation. It is not
+// case-sensitive.
+func ParseRootKey(s string) (RootKey, error) {
+	key := RootKey(strings.ToUpper(strings.TrimSpace(s)))
+	switch key {
+	case HKCR, HKCU, HKLM, HKU, HKR:
+		return key, nil
+	default:
+		return key, fmt.Errorf("\"%s\" is not a registry root key", s)
+	}
+}
+
+// Name returns the full name of the root key.
+func (k RootKey) Name() string {
+	switch k {
+	case HKCR:
+		return "HKEY_CLASSES_ROOT"
+	case HKCU:
+		return "HKEY_CURRENT_USER"
+	case HKLM:
+		return "HKEY_LOCAL_MACHINE"
+	case HKU:
+		return "HKEY_USERS"
+	default:
+		return string(k)
+	}
+}
+
+// ValueType is a registry value type.
+type ValueType uint32
+
+// Registry value types.
+//
+// https://docs.microsoft.com/en-us/windows/win32/sysinfo/registry-value-types
+const (
+	RegNone                     ValueType = 0  // REG_NONE
+	RegSZ                       ValueType = 1  // REG_SZ
+	RegExpandSZ                 ValueType = 2  // REG_EXPAND_SZ
+	RegBinary                   ValueType = 3  // REG_BINARY
+	RegDWORD                    ValueType = 4  // REG_DWORD
+	RegDWORDBigEndian           ValueType = 5  // REG_DWORD_BIG_ENDIAN
+	RegLink                     ValueType = 6  // REG_LINK
+	RegMultiSZ                  ValueType = 7  // REG_MULTI_SZ
+	RegResourceList             ValueType = 8  // REG_RESOURCE_LIST
+	RegFullResourceDescriptor   ValueType = 9  // REG_FULL_RESOURCE_DESCRIPTOR
+	RegResourceRequirementsList ValueType = 10 // REG_RESOURCE_REQUIREMENTS_LIST
+	RegQWORD                    ValueType = 11 // REG_QWORD
+)
+
+// String returns the Windows name of the value type.
+func (t ValueType) String() string {
+	switch t {
+	case RegNone:
+		return "REG_NONE"
+	case RegSZ:
+		return "REG_SZ"
+	case RegExpandSZ:
+		return "REG_EXPAND_SZ"
+	case RegBinary:
+		return "REG_BINARY"
+	case RegDWORD:
+		return "REG_DWORD"
+	case RegDWORDBigEndian:
+		return "REG_DWORD_BIG_ENDIAN"
+	case RegLink:
+		return "REG_LINK"
+	case RegMultiSZ:
+		return "REG_MULTI_SZ"
+	case RegResourceList:
+		return "REG_RESOURCE_LIST"
+	case RegFullResourceDescriptor:
+		return "REG_FULL_RESOURCE_DESCRIPTOR"
+	case RegResourceRequirementsList:
+		return "REG_RESOURCE_REQUIREMENTS_LIST"
+	case RegQWORD:
+		return "REG_QWORD"
+	default:
+		return fmt.Sprintf("Unknown ValueType %d", t)
+	}
+}
+
+// Flags used by AddReg, DelReg and BitReg entries. The key view flags
+// have the same values in all three.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-addreg-directive
+const (
+	AddRegBinValueType  = 0x00000001 // FLG_ADDREG_BINVALUETYPE
+	AddRegNoClobber     = 0x00000002 // FLG_ADDREG_NOCLOBBER
+	AddRegDelVal        = 0x00000004 // FLG_ADDREG_DELVAL
+	AddRegAppend        = 0x00000008 // FLG_ADDREG_APPEND
+	AddRegKeyOnly       = 0x00000010 // FLG_ADDREG_KEYONLY
+	AddRegOverwriteOnly = 0x00000020 // FLG_ADDREG_OVERWRITEONLY
+	AddReg64BitKey      = 0x00001000 // FLG_ADDREG_64BITKEY
+	AddRegKeyOnlyCommon = 0x00002000 // FLG_ADDREG_KEYONLY_COMMON
+	AddReg32BitKey      = 0x00004000 // FLG_ADDREG_32BITKEY
+	AddRegTypeMask      = 0xFFFF0001 // FLG_ADDREG_TYPE_MASK
+	AddRegTypeSZ        = 0x00000000 // FLG_ADDREG_TYPE_SZ
+	AddRegTypeMultiSZ   = 0x00010000 // FLG_ADDREG_TYPE_MULTI_SZ
+	AddRegTypeExpandSZ  = 0x00020000 // FLG_ADDREG_TYPE_EXPAND_SZ
+	AddRegTypeBinary    = 0x00000001 // FLG_ADDREG_TYPE_BINARY
+	AddRegTypeDWORD     = 0x00010001 // FLG_ADDREG_TYPE_DWORD
+	AddRegTypeNone      = 0x00020001 // FLG_ADDREG_TYPE_NONE
+	DelRegMultiSZDelete = 0x00018002 // FLG_DELREG_MULTI_SZ_DELSTRING
+	BitRegSetBits       = 0x00000001 // FLG_BITREG_SETBITS
+	regKeyViewFlagsMask = AddReg64BitKey | AddRegKeyOnlyCommon | AddReg32BitKey
+)
+
+// RegistryAction identifies the directive that produced a registry entry.
+type RegistryAction int
+
+// Registry actions.
+const (
+	AddReg RegistryAction = 1
+	DelReg RegistryAction = 2
+	BitReg RegistryAction = 3
+)
+
+// String returns the name of the directive.
+func (a RegistryAction) String() string {
+	switch a {
+	case AddReg:
+		return "AddReg"
+	case DelReg:
+		return "DelReg"
+	case BitReg:
+		return "BitReg"
+	default:
+		return fmt.Sprintf("Unknown RegistryAction %d", a)
+	}
+}
+
+// RegistryEntry is a line of an add-registry, del-registry or bit-registry
+// section.
+//
+// The meaning of the HKR root key depends on the section that
+// references the entry. In a DDInstall section it is the driver's
+// software key, in a DDInstall.HW section it is the device's hardware key
+// and in a service-install section it is the service key.
+type RegistryEntry struct {
+	Action  RegistryAction
+	Root    RootKey
+	Subkey  string
+	Name    string    // Value name, or empty for the default value
+	Flags   uint32    // FLG_ADDREG, FLG_DELREG or FLG_BITREG flags
+	Type    ValueType // Value type for AddReg entries and multi-string deletions
+	Values  []string  // Value data for AddReg and DelReg entries
+	Mask    uint8     // Bit mask for BitReg entries
+	Byte    uint32    // Zero-based index of the byte modified by BitReg entries
+	Section string
+	Line    int
+}
+
+// Key returns the registry key path of the entry, including its root.
+func (r RegistryEntry) Key() string {
+	if r.Subkey == "" {
+		return string(r.Root)
+	}
+	return string(r.Root) + `\` + r.Subkey
+}
+
+// Options returns the names of the flags that modify the entry's
+// operation, such as "NoClobber" or "64BitKey".
+func (r RegistryEntry) Options() []string {
+	var options []string
+	add := func(flag uint32, name string) {
+		if r.Flags&flag == flag {
+			options = append(options, name)
+		}
+	}
+	switch r.Action {
+	case AddReg:
+		add(AddRegNoClobber, "NoClobber")
+		add(AddRegDelVal, "DelVal")
+		add(AddRegAppend, "Append")
+		add(AddRegKeyOnly, "KeyOnly")
+		add(AddRegOverwriteOnly, "OverwriteOnly")
+	case DelReg:
+		if r.Flags&^regKeyViewFlagsMask == DelRegMultiSZDelete {
+			options = append(options, "MultiSZDelString")
+		}
+	case BitReg:
+		if r.Flags&BitRegSetBits != 0 {
+			options = append(options, "SetBits")
+		} else {
+			options = append(options, "ClearBits")
+		}
+	}
+	add(AddReg64BitKey, "64BitKey")
+	add(AddReg32BitKey, "32BitKey")
+	add(AddRegKeyOnlyCommon, "KeyOnlyCommon")
+	return options
+}
+
+// Data returns a human readable representation of the entry's value data,
+// decoded according to its type.
+func (r RegistryEntry) Data() string {
+	switch r.Action {
+	case BitReg:
+		return fmt.Sprintf("mask 0x%02x of byte %d", r.Mask, r.Byte)
+	case DelReg:
+		if len(r.Values) == 0 {
+			return ""
+		}
+		return quote(strings.Join(r.Values, ","))
+	}
+
+	switch r.Type {
+	case RegSZ, RegExpandSZ:
+		return quote(strings.Join(r.Values, ","))
+	case RegMultiSZ:
+		quoted := make([]string, len(r.Values))
+		for i, value := range r.Values {
+			quoted[i] = quote(value)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	case RegDWORD:
+		if len(r.Values) == 1 {
+			if v, err := parseNumber(r.Values[0]); err == nil {
+				return fmt.Sprintf("0x%08x (%d)", v, v)
+			}
+		}
+		if data, err := parseBytes(r.Values); err == nil && len(data) == 4 {
+			v := binary.LittleEndian.Uint32(data)
+			return fmt.Sprintf("0x%08x (%d)", v, v)
+		}
+	case RegQWORD:
+		if data, err := parseBytes(r.Values); err == nil && len(data) == 8 {
+			v := binary.LittleEndian.Uint64(data)
+			return fmt.Sprintf("0x%016x (%d)", v, v)
+		}
+	default:
+		if data, err := parseBytes(r.Values); err == nil {
+			return fmt.Sprintf("% x", data)
+		}
+	}
+	return strings.Join(r.Values, ",")
+}
+
+// String returns a one-line description of the entry.
+func (r RegistryEntry) String() string {
+	s := r.Action.String() + " " + r.Key()
+	keyOnly := r.Action == AddReg && r.Flags&(AddRegKeyOnly|AddRegKeyOnlyCommon) != 0
+	switch {
+	case keyOnly:
+	case r.Name != "":
+		s += " " + quote(r.Name)
+	case r.Action != DelReg:
+		s += " (default)"
+	}
+	switch {
+	case r.Action == AddReg && !keyOnly && r.Flags&AddRegDelVal == 0:
+		s += " " + r.Type.String() + " = " + r.Data()
+	case r.Action != AddReg:
+		if data := r.Data(); data != "" {
+			s += " " + data
+		}
+	}
+	if options := r.Options(); len(options) > 0 {
+		s += " [" + strings.Join(options, ",") + "]"
+	}
+	return s
+}
+
+// Registry returns the registry entries referenced by the AddReg, DelReg
+// and BitReg directives of a section, in the order they appear. Sections
+// that do not exist are skipped, as are lines that do not start with a
+// registry root key. Use InvalidRegistry to find the skipped lines.
+func (d *Document) Registry(section *Section) []RegistryEntry {
+	var entries []RegistryEntry
+	d.registry(section, func(r RegistryEntry, err error) {
+		if err == nil {
+			entries = append(entries, r)
+		}
+	})
+	return entries
+}
+
+// RegistryError describes a line of a registry section that could not be
+// parsed.
+type RegistryError struct {
+	Section string
+	Line    int
+	Err     error
+}
+
+// Error returns a string describing the error.
+func (e RegistryError) Error() string {
+	return fmt.Sprintf("line %d: registry entry in [%s] is invalid: %v", e.Line, e.Section, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e RegistryError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidRegistry returns the lines of the registry sections referenced by
+// a section that Registry skips because they cannot be parsed.
+func (d *Document) InvalidRegistry(section *Section) []RegistryError {
+	var invalid []RegistryError
+	d.registry(section, func(r RegistryEntry, err error) {
+		if err != nil {
+			invalid = append(invalid, RegistryError{Section: r.Section, Line: r.Line, Err: err})
+		}
+	})
+	return invalid
+}
+
+// registry calls fn for each line of the registry sections referenced by
+// the AddReg, DelReg and BitReg directives of section.
+func (d *Document) registry(section *Section, fn func(RegistryEntry, error)) {
+	if section == nil {
+		return
+	}
+	for _, directive := range section.Entries {
+		var action RegistryAction
+		switch {
+		case strings.EqualFold(directive.Key, "AddReg"):
+			action = AddReg
+		case strings.EqualFold(directive.Key, "DelReg"):
+			action = DelReg
+		case strings.EqualFold(directive.Key, "BitReg"):
+			action = BitReg
+		default:
+			continue
+		}
+		for _, name := range directive.Values {
+			list := d.Section(name)
+			if list == nil {
+				continue
+			}
+			for _, entry := range list.Entries {
+				fn(parseRegistryEntry(action, list.Name, d.registryFields(entry), entry.Line))
+			}
+		}
+	}
+}
+
+// registryFields returns the expanded fields of a registry section line.
+// Registry lines don't have keys, but an unquoted equals sign in a subkey,
+// value name or value causes the line to be split there, so the fields of
+// such lines are rebuilt from the raw text.
+func (d *Document) registryFields(entry Entry) []string {
+	if entry.RawKey == "" {
+		return entry.Values
+	}
+	raw := splitFields(entry.RawKey + "=" + strings.Join(entry.RawValues, ","))
+	fields := make([]string, len(raw))
+	for i := range raw {
+		fields[i], _ = expand(raw[i], d.strings)
+	}
+	return fields
+}
+
+// parseRegistryEntry parses the fields of a line of a registry section. It
+// returns an error if the line does not start with a registry root key.
+// The section and line are recorded in the returned entry either way.
+func parseRegistryEntry(action RegistryAction, section string, fields []string, line int) (r RegistryEntry, err error) {
+	field := func(i int) string {
+		if i < len(fields) {
+			return fields[i]
+		}
+		return ""
+	}
+
+	root, err := ParseRootKey(field(0))
+	if err != nil {
+		return RegistryEntry{Action: action, Section: section, Line: line}, err
+	}
+
+	r = RegistryEntry{
+		Action:  action,
+		Root:    root,
+		Subkey:  field(1),
+		Name:    field(2),
+		Section: section,
+		Line:    line,
+	}
+	r.Flags, _ = parseNumber(field(3))
+
+	switch action {
+	case AddReg:
+		r.Type = addRegType(r.Flags)
+		if len(fields) > 4 {
+			r.Values = fields[4:]
+		}
+	case DelReg:
+		if r.Flags&^regKeyViewFlagsMask == DelRegMultiSZDelete {
+			r.Type = RegMultiSZ
+		}
+		if len(fields) > 4 {
+			r.Values = fields[4:]
+		}
+	case BitReg:
+		mask, _ := parseNumber(field(4))
+		r.Mask = uint8(mask)
+		r.Byte, _ = parseNumber(field(5))
+	}
+	return r, nil
+}
+
+// addRegType returns the value type encoded in AddReg flags.
+func addRegType(flags uint32) ValueType {
+	switch flags & AddRegTypeMask {
+	case AddRegTypeSZ:
+		return RegSZ
+	case AddRegTypeMultiSZ:
+		return RegMultiSZ
+	case AddRegTypeExpandSZ:
+		return RegExpandSZ
+	case AddRegTypeBinary:
+		return RegBinary
+	case AddRegTypeDWORD:
+		return RegDWORD
+	case AddRegTypeNone:
+		return RegNone
+	}
+	if flags&AddRegBinValueType != 0 {
+		// Other binary types carry the registry type in the high word
+		return ValueType(flags >> 16)
+	}
+	return RegSZ
+}
+
+// parseBytes parses a list of hexadecimal byte values, such as those
+// used by binary AddReg entries.
+func parseBytes(values []string) ([]byte, error) {
+	var data []byte
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+		b, err := strconv.ParseUint(value, 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("\"%s\" is not a hexadecimal byte", value)
+		}
+		data = append(data, byte(b))
+	}
+	return data, nil
+}
+
+// quote surrounds s with double quotes. Unlike strconv.Quote it leaves
+// backslashes alone, so that registry paths remain readable.
+func quote(s string) string {
+	return `"` + s + `"`
+}
@@ -0,0 +1,75 @@
+package inf
+
+import "strings"
+
+// Section is a named section of an INF file.
+type Section struct {
+	Name    string
+	Line    int // Line number of the first section header
+	Entries []Entry
+}
+
+// Entry returns the first entry with the given key. The key is not
+// case-sensitive.
+func (s *Section) Entry(key string) (entry Entry, ok bool) {
+	if s == nil {
+		return Entry{}, false
+	}
+	for _, entry := range s.Entries {
+		if entry.Key != "" && strings.EqualFold(entry.Key, key) {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Find returns all entries with the given key. The key is not
+// case-sensitive.
+func (s *Section) Find(key string) []Entry {
+	if s == nil {
+		return nil
+	}
+	var entries []Entry
+	for _, entry := range s.Entries {
+		if entry.Key != "" && strings.EqualFold(entry.Key, key) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Value returns the first value of the first entry with the given key.
+// It returns an empty string if the key does not exist.
+func (s *Section) Value(key string) string {
+	entry, _ := s.Entry(key)
+	return entry.Value(0)
+}
+
+// Values returns the values of the first entry with the given key. It
+// returns nil if the key does not exist.
+func (s *Section) Values(key string) []string {
+	entry, _ := s.Entry(key)
+	return entry.Values
+}
+
+// Entry is a single line within a section of an INF file, such as
+// "CopyFiles = Drivers_Dir" or "mydriver.sys,,,2".
+//
+// Entries that have no equals sign have an empty key.
+type Entry struct {
+	Line   int      // Line number where the entry begins
+	Key    string   // Expanded key, or empty if the entry has no key
+	Values []string // Expanded values
+
+	RawKey    string   // Key as it appears in the file
+	RawValues []string // Values as they appear in the file, with quotes
+}
+
+// Value returns the expanded value at index i. It returns an empty string
+// if the value does not exist.
+func (e Entry) Value(i int) string {
+	if i < 0 || i >= len(e.Values) {
+		return ""
+	}
+	return e.Values[i]
+}
@@ -0,0 +1,115 @@
+package inf
+
+import "fmt"
+
+// Selection describes the models section that Windows would use for a
+// manufacturer on a particular platform.
+type Selection struct {
+	Manufacturer Manufacturer
+	Platform     Platform
+
+	// Section is the name of the selected models section. It is empty if
+	// no models section applies to the platform.
+	Section string
+
+	// Decoration is the selected decoration, as it appears in the file. It
+	// is empty if no decoration was selected.
+	Decoration string
+
+	// Evaluations describes every models section that was considered,
+	// including the undecorated section, in the order they appear in the
+	// [Manufacturer] entry.
+	Evaluations []Evaluation
+}
+
+// Selected returns true if a models section applies to the platform.
+func (s Selection) Selected() bool {
+	return s.Section != ""
+}
+
+// Evaluation describes the outcome of evaluating a single models section
+// for a platform.
+type Evaluation struct {
+	Section    string // Models section name
+	Decoration string // Decoration as it appears in the file, or empty
+	Selected   bool
+	Reason     string // Why the section was selected or rejected
+}
+
+// Select evaluates the manufacturer's models sections for a platform and
+// returns the one that Windows would use, along with the reasons that the
+// others were rejected.
+//
+// When one or more decorations match the platform, the most specific one
+// is used. The undecorated models section is only used on x86 platforms
+// when no decoration matches.
+func (m Manufacturer) Select(p Platform) Selection {
+	sel := Selection{
+		Manufacturer: m,
+		Platform:     p,
+	}
+
+	var (
+		best       Decoration
+		bestIndex  = -1
+		candidates []int // Indices of matching evaluations
+	)
+
+	for _, raw := range m.Decorations {
+		eval := Evaluation{
+			Section:    m.Section + "." + raw,
+			Decoration: raw,
+		}
+		decoration, err := ParseDecoration(raw)
+		if err == nil {
+			err = decoration.Evaluate(p)
+		}
+		if err != nil {
+			eval.Reason = err.Error()
+		} else {
+			candidates = append(candidates, len(sel.Evaluations))
+			if bestIndex < 0 || moreSpecific(decoration, best) {
+				best, bestIndex = decoration, len(sel.Evaluations)
+			}
+		}
+		sel.Evaluations = append(sel.Evaluations, eval)
+	}
+
+	undecorated := Evaluation{Section: m.Section}
+	switch {
+	case bestIndex >= 0:
+		undecorated.Reason = "a decorated models section matches the platform"
+	case p.Architecture != X86:
+		undecorated.Reason = fmt.Sprintf("undecorated models sections only apply to %s, not %s", X86, p.Architecture)
+	default:
+		undecorated.Selected = true
+		undecorated.Reason = "no decorated models section matches the platform"
+		sel.Section = m.Section
+	}
+	sel.Evaluations = append(sel.Evaluations, undecorated)
+
+	if bestIndex >= 0 {
+		chosen := &sel.Evaluations[bestIndex]
+		chosen.Selected = true
+		chosen.Reason = "most specific decoration that matches the platform"
+		sel.Section, sel.Decoration = chosen.Section, chosen.Decoration
+		for _, i := range candidates {
+			if i != bestIndex {
+				sel.Evaluations[i].Reason = fmt.Sprintf("matches the platform but %s is more specific", chosen.Decoration)
+			}
+		}
+	}
+
+	return sel
+}
+
+// Select evaluates the models sections of every manufacturer in the
+// document for a platform.
+func (d *Document) Select(p Platform) []Selection {
+	manufacturers := d.Manufacturers()
+	selections := make([]Selection, 0, len(manufacturers))
+	for _, m := range manufacturers {
+		selections = append(selections, m.Select(p))
+	}
+	return selections
+}
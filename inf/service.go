@@ -0,0 +1,288 @@
+package inf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Flags used by AddService directives.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-addservice-directive
+const (
+	ServiceTagToFront                = 0x00000001 // SPSVCINST_TAGTOFRONT
+	ServiceAssocService              = 0x00000002 // SPSVCINST_ASSOCSERVICE
+	ServiceDeleteEventLogEntry       = 0x00000004 // SPSVCINST_DELETEEVENTLOGENTRY
+	ServiceNoClobberDisplayName      = 0x00000008 // SPSVCINST_NOCLOBBER_DISPLAYNAME
+	ServiceNoClobberStartType        = 0x00000010 // SPSVCINST_NOCLOBBER_STARTTYPE
+	ServiceNoClobberErrorControl     = 0x00000020 // SPSVCINST_NOCLOBBER_ERRORCONTROL
+	ServiceNoClobberLoadOrderGroup   = 0x00000040 // SPSVCINST_NOCLOBBER_LOADORDERGROUP
+	ServiceNoClobberDependencies     = 0x00000080 // SPSVCINST_NOCLOBBER_DEPENDENCIES
+	ServiceNoClobberDescription      = 0x00000100 // SPSVCINST_NOCLOBBER_DESCRIPTION
+	ServiceStopService               = 0x00000200 // SPSVCINST_STOPSERVICE
+	ServiceClobberSecurity           = 0x00000400 // SPSVCINST_CLOBBER_SECURITY
+	ServiceStartService              = 0x00000800 // SPSVCINST_STARTSERVICE
+	ServiceNoClobberRequiredPrivs    = 0x00001000 // SPSVCINST_NOCLOBBER_REQUIREDPRIVILEGES
+	ServiceNoClobberTriggers         = 0x00002000 // SPSVCINST_NOCLOBBER_TRIGGERS
+	ServiceNoClobberServiceSIDType   = 0x00004000 // SPSVCINST_NOCLOBBER_SERVICESIDTYPE
+	ServiceNoClobberDelayedAutoStart = 0x00008000 // SPSVCINST_NOCLOBBER_DELAYEDAUTOSTART
+	ServiceUniqueName                = 0x00010000 // SPSVCINST_UNIQUE_NAME
+)
+
+var serviceFlagNames = []struct {
+	flag uint32
+	name string
+}{
+	{ServiceTagToFront, "TagToFront"},
+	{ServiceAssocService, "AssocService"},
+	{ServiceDeleteEventLogEntry, "DeleteEventLogEntry"},
+	{ServiceNoClobberDisplayName, "NoClobberDisplayName"},
+	{ServiceNoClobberStartType, "NoClobberStartType"},
+	{ServiceNoClobberErrorControl, "NoClobberErrorControl"},
+	{ServiceNoClobberLoadOrderGroup, "NoClobberLoadOrderGroup"},
+	{ServiceNoClobberDependencies, "NoClobberDependencies"},
+	{ServiceNoClobberDescription, "NoClobberDescription"},
+	{ServiceStopService, "StopService"},
+	{ServiceClobberSecurity, "ClobberSecurity"},
+	{ServiceStartService, "StartService"},
+	{ServiceNoClobberRequiredPrivs, "NoClobberRequiredPrivileges"},
+	{ServiceNoClobberTriggers, "NoClobberTriggers"},
+	{ServiceNoClobberServiceSIDType, "NoClobberServiceSidType"},
+	{ServiceNoClobberDelayedAutoStart, "NoClobberDelayedAutoStart"},
+	{ServiceUniqueName, "UniqueName"},
+}
+
+// ServiceType is the type of a service.
+type ServiceType uint32
+
+// Service types.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-addservice-directive
+const (
+	KernelDriver       ServiceType = 0x00000001 // SERVICE_KERNEL_DRIVER
+	FileSystemDriver   ServiceType = 0x00000002 // SERVICE_FILE_SYSTEM_DRIVER
+	Win32OwnProcess    ServiceType = 0x00000010 // SERVICE_WIN32_OWN_PROCESS
+	Win32ShareProcess  ServiceType = 0x00000020 // SERVICE_WIN32_SHARE_PROCESS
+	InteractiveProcess ServiceType = 0x00000100 // SERVICE_INTERACTIVE_PROCESS
+)
+
+// String returns a string representation of the service type.
+func (t ServiceType) String() string {
+	name := func(t ServiceType) string {
+		switch t {
+		case KernelDriver:
+			return "KernelDriver"
+		case FileSystemDriver:
+			return "FileSystemDriver"
+		case Win32OwnProcess:
+			return "Win32OwnProcess"
+		case Win32ShareProcess:
+			return "Win32ShareProcess"
+		default:
+			return fmt.Sprintf("Unknown ServiceType %d", t)
+		}
+	}
+	if t&InteractiveProcess != 0 && t != InteractiveProcess {
+		return name(t&^InteractiveProcess) + "|InteractiveProcess"
+	}
+	return name(t)
+}
+
+// StartType determines when a service is started.
+type StartType uint32
+
+// Service start types.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-addservice-directive
+const (
+	BootStart   StartType = 0 // SERVICE_BOOT_START
+	SystemStart StartType = 1 // SERVICE_SYSTEM_START
+	AutoStart   StartType = 2 // SERVICE_AUTO_START
+	DemandStart StartType = 3 // SERVICE_DEMAND_START
+	Disabled    StartType = 4 // SERVICE_DISABLED
+)
+
+// String returns a string representation of the start type.
+func (t StartType) String() string {
+	switch t {
+	case BootStart:
+		return "Boot"
+	case SystemStart:
+		return "System"
+	case AutoStart:
+		return "Auto"
+	case DemandStart:
+		return "Demand"
+	case Disabled:
+		return "Disabled"
+	default:
+		return fmt.Sprintf("Unknown StartType %d", t)
+	}
+}
+
+// ErrorControl determines how the system responds when a service fails to
+// start.
+type ErrorControl uint32
+
+// Service error control levels.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-addservice-directive
+const (
+	ErrorIgnore   ErrorControl = 0 // SERVICE_ERROR_IGNORE
+	ErrorNormal   ErrorControl = 1 // SERVICE_ERROR_NORMAL
+	ErrorSevere   ErrorControl = 2 // SERVICE_ERROR_SEVERE
+	ErrorCritical ErrorControl = 3 // SERVICE_ERROR_CRITICAL
+)
+
+// String returns a string representation of the error control level.
+func (e ErrorControl) String() string {
+	switch e {
+	case ErrorIgnore:
+		return "Ignore"
+	case ErrorNormal:
+		return "Normal"
+	case ErrorSevere:
+		return "Severe"
+	case ErrorCritical:
+		return "Critical"
+	default:
+		return fmt.Sprintf("Unknown ErrorControl %d", e)
+	}
+}
+
+// Service is a service installed by an AddService directive.
+//
+// Registry entries in the service-install section are relative to the
+// service key. Registry entries in the event-log-install section are
+// relative to the event source key.
+type Service struct {
+	Name           string // Service name, empty for a null driver
+	Flags          uint32 // SPSVCINST flags
+	InstallSection string
+	DisplayName    string
+	Description    string
+	ServiceType    ServiceType
+	StartType      StartType
+	HasStartType   bool // False if the required StartType directive is missing
+	ErrorControl   ErrorControl
+	ServiceBinary  string
+	StartName      string
+	LoadOrderGroup string
+	Dependencies   []string // Service names, or load order groups prefixed by "+"
+	Security       string   // SDDL security descriptor
+	Registry       []RegistryEntry
+
+	EventLogSection  string
+	EventLogType     string // Application, System or Security
+	EventName        string
+	EventLogRegistry []RegistryEntry
+
+	Section string // Section containing the AddService directive
+	Line    int
+}
+
+// Options returns the names of the SPSVCINST flags set for the service.
+func (s Service) Options() []string {
+	var options []string
+	for _, f := range serviceFlagNames {
+		if s.Flags&f.flag != 0 {
+			options = append(options, f.name)
+		}
+	}
+	return options
+}
+
+// Function returns true if the service is installed as the function driver
+// of the device.
+func (s Service) Function() bool {
+	return s.Flags&ServiceAssocService != 0
+}
+
+// Services returns the services installed by the AddService directives of
+// a section, which is normally a DDInstall.Services section. Missing
+// service-install and event-log-install sections leave the corresponding
+// fields empty.
+func (d *Document) Services(section *Section) []Service {
+	var services []Service
+	for _, directive := range section.Find("AddService") {
+		// ServiceName,[flags],service-install-section
+		//   [,event-log-install-section[,[EventLogType][,EventName]]]
+		service := Service{
+			Name:            directive.Value(0),
+			InstallSection:  directive.Value(2),
+			EventLogSection: directive.Value(3),
+			EventLogType:    directive.Value(4),
+			EventName:       directive.Value(5),
+			Section:         section.Name,
+			Line:            directive.Line,
+		}
+		service.Flags, _ = parseNumber(directive.Value(1))
+		if service.EventLogSection != "" {
+			if service.EventLogType == "" {
+				service.EventLogType = "System"
+			}
+			if service.EventName == "" {
+				service.EventName = service.Name
+			}
+		}
+
+		if install := d.Section(service.InstallSection); install != nil {
+			number := func(key string) uint32 {
+				v, _ := parseNumber(install.Value(key))
+				return v
+			}
+			service.DisplayName = install.Value("DisplayName")
+			service.Description = install.Value("Description")
+			service.ServiceType = ServiceType(number("ServiceType"))
+			if entry, ok := install.Entry("StartType"); ok {
+				v, _ := parseNumber(entry.Value(0))
+				service.StartType, service.HasStartType = StartType(v), true
+			}
+			service.ErrorControl = ErrorControl(number("ErrorControl"))
+			service.ServiceBinary = install.Value("ServiceBinary")
+			service.StartName = install.Value("StartName")
+			service.LoadOrderGroup = install.Value("LoadOrderGroup")
+			service.Security = install.Value("Security")
+			for _, entry := range install.Find("Dependencies") {
+				for _, dependency := range entry.Values {
+					if dependency != "" {
+						service.Dependencies = append(service.Dependencies, dependency)
+					}
+				}
+			}
+			service.Registry = d.Registry(install)
+		}
+		if service.EventLogSection != "" {
+			service.EventLogRegistry = d.Registry(d.Section(service.EventLogSection))
+		}
+
+		services = append(services, service)
+	}
+	return services
+}
+
+// String returns a one-line description of the service.
+func (s Service) String() string {
+	name := s.Name
+	if name == "" {
+		name = "(null driver)"
+	}
+	var b strings.Builder
+	b.WriteString(name)
+	if s.InstallSection != "" {
+		if s.HasStartType {
+			fmt.Fprintf(&b, " %s, %s start", s.ServiceType, s.StartType)
+		} else {
+			fmt.Fprintf(&b, " %s, missing StartType", s.ServiceType)
+		}
+	}
+	if s.LoadOrderGroup != "" {
+		fmt.Fprintf(&b, ", group %q", s.LoadOrderGroup)
+	}
+	if s.ServiceBinary != "" {
+		fmt.Fprintf(&b, ", %s", s.ServiceBinary)
+	}
+	if options := s.Options(); len(options) > 0 {
+		fmt.Fprintf(&b, " [%s]", strings.Join(options, ","))
+	}
+	return b.String()
+}
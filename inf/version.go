@@ -0,0 +1,157 @@
+package inf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gentlemanautomaton/windevice/driverversion"
+)
+
+// Version holds the metadata from the [Version] section of an INF file.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-version-section
+type Version struct {
+	Signature string // $Windows NT$, $Chicago$ or $Windows 95$
+	Class     string
+	ClassGUID string // In registry format, such as {4d36e972-e325-11ce-bfc1-08002be10318}
+	Provider  string
+
+	// DriverDate and DriverVersion are taken from the DriverVer directive.
+	// They are only meaningful when HasDriverVer is true.
+	DriverDate    time.Time
+	DriverVersion driverversion.Value
+	HasDriverVer  bool
+
+	// CatalogFiles maps the platform extension of each CatalogFile
+	// directive to its file name. The undecorated CatalogFile directive
+	// has an empty extension, CatalogFile.NT has an extension of NT and
+	// CatalogFile.NTamd64 has an extension of NTamd64.
+	CatalogFiles map[string]string
+
+	// PnpLockdown is true when the PnpLockdown directive is set to 1.
+	PnpLockdown bool
+
+	DriverPackageType        string
+	DriverPackageDisplayName string
+}
+
+// ReadVersion reads the [Version] metadata of the INF file at path.
+func ReadVersion(path string) (Version, error) {
+	doc, err := Load(path)
+	if err != nil {
+		return Version{}, err
+	}
+	return doc.Version()
+}
+
+// Version returns the metadata from the [Version] section of the
+// document. If the DriverVer directive is invalid, the rest of the metadata
+// is returned along with the error.
+func (d *Document) Version() (Version, error) {
+	section := d.Section("Version")
+	if section == nil {
+		return Version{}, errors.New("the inf file does not have a [Version] section")
+	}
+
+	v := Version{
+		Signature:                section.Value("Signature"),
+		Class:                    section.Value("Class"),
+		ClassGUID:                section.Value("ClassGuid"),
+		Provider:                 section.Value("Provider"),
+		PnpLockdown:              strings.TrimSpace(section.Value("PnpLockdown")) == "1",
+		DriverPackageType:        section.Value("DriverPackageType"),
+		DriverPackageDisplayName: section.Value("DriverPackageDisplayName"),
+	}
+
+	for _, entry := range section.Entries {
+		const prefix = "catalogfile"
+		key := strings.ToLower(entry.Key)
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		var ext string
+		switch rest := entry.Key[len(prefix):]; {
+		case rest == "":
+		case rest[0] == '.':
+			ext = rest[1:]
+		default:
+			continue
+		}
+		if v.CatalogFiles == nil {
+			v.CatalogFiles = make(map[string]string)
+		}
+		v.CatalogFiles[ext] = entry.Value(0)
+	}
+
+	if entry, ok := section.Entry("DriverVer"); ok {
+		date, version, err := ParseDriverVer(entry.Values)
+		if err != nil {
+			return v, fmt.Errorf("line %d: %v", entry.Line, err)
+		}
+		v.DriverDate, v.DriverVersion, v.HasDriverVer = date, version, true
+	}
+
+	return v, nil
+}
+
+// CatalogFile returns the catalog file that applies to the given processor
+// architecture. The most specific CatalogFile directive is used. It returns
+// an empty string if no catalog file applies.
+func (v Version) CatalogFile(arch Architecture) string {
+	for ext, file := range v.CatalogFiles {
+		if strings.EqualFold(ext, arch.Extension()) {
+			return file
+		}
+	}
+	for ext, file := range v.CatalogFiles {
+		if strings.EqualFold(ext, "NT") {
+			return file
+		}
+	}
+	return v.CatalogFiles[""]
+}
+
+// ValidateClass returns an error if the metadata does not identify a
+// device setup class, or if the class GUID is malformed.
+func (v Version) ValidateClass() error {
+	if v.Class == "" && v.ClassGUID == "" {
+		return errors.New("the inf file does not specify a Class or ClassGuid in its [Version] section")
+	}
+	if v.ClassGUID != "" && !isGUID(v.ClassGUID) {
+		return fmt.Errorf("the inf file has an invalid ClassGuid \"%s\"", v.ClassGUID)
+	}
+	return nil
+}
+
+// ParseDriverVer parses the values of a DriverVer directive in the form
+// mm/dd/yyyy[,w.x.y.z]. The date is returned in UTC.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/inf-driverver-directive
+func ParseDriverVer(values []string) (date time.Time, version driverversion.Value, err error) {
+	if len(values) == 0 || values[0] == "" {
+		return time.Time{}, 0, errors.New("the DriverVer directive does not have a date")
+	}
+	date, err = parseDriverDate(values[0])
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if len(values) > 1 && values[1] != "" {
+		version, err = driverversion.Parse(values[1])
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+	}
+	return date, version, nil
+}
+
+func parseDriverDate(s string) (time.Time, error) {
+	// Leading zeros are optional in both the month and day
+	for _, layout := range []string{"1/2/2006", "1-2-2006"} {
+		if date, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("the DriverVer date \"%s\" is not in mm/dd/yyyy format", s)
+}
@@ -0,0 +1,273 @@
+package inflint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gentlemanautomaton/windevice/driverpackage"
+	"github.com/gentlemanautomaton/windevice/inf"
+)
+
+// CheckFile parses the INF file at path and checks it for problems. In
+// addition to the checks performed by Check, it verifies that the files
+// required by the package exist in the directory that contains the INF
+// file.
+func CheckFile(path string) []Finding {
+	doc, err := inf.Load(path)
+	if err != nil {
+		return []Finding{{
+			File:     path,
+			Code:     ParseFailed.Code,
+			Severity: ParseFailed.Severity,
+			Message:  err.Error(),
+		}}
+	}
+	findings := check(doc, path)
+	for i := range findings {
+		findings[i].File = path
+	}
+	return findings
+}
+
+// Check checks a parsed INF document for problems. The findings are
+// sorted by line number.
+func Check(doc *inf.Document) []Finding {
+	return check(doc, "")
+}
+
+func check(doc *inf.Document, path string) []Finding {
+	c := checker{doc: doc}
+	c.checkVersion()
+	c.checkStrings()
+	c.checkManufacturers()
+	c.checkCatalogFiles()
+	c.checkRegistry()
+	if path != "" {
+		c.checkPackageFiles(path)
+	}
+	sort.SliceStable(c.findings, func(i, j int) bool {
+		return c.findings[i].Line < c.findings[j].Line
+	})
+	return c.findings
+}
+
+type checker struct {
+	doc      *inf.Document
+	findings []Finding
+	version  inf.Version
+	hasVer   bool                      // Whether version was read successfully
+	archs    map[inf.Architecture]bool // Architectures targeted by models sections
+	reported map[Finding]bool          // Prevents duplicate findings
+}
+
+func (c *checker) report(rule Rule, line int, format string, args ...interface{}) {
+	finding := Finding{
+		Line:     line,
+		Code:     rule.Code,
+		Severity: rule.Severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if c.reported[finding] {
+		return
+	}
+	if c.reported == nil {
+		c.reported = make(map[Finding]bool)
+	}
+	c.reported[finding] = true
+	c.findings = append(c.findings, finding)
+}
+
+func (c *checker) checkVersion() {
+	section := c.doc.Section("Version")
+	if section == nil {
+		c.report(MissingVersion, 0, "the file does not have a [Version] section")
+		return
+	}
+
+	class := inf.Version{Class: section.Value("Class"), ClassGUID: section.Value("ClassGuid")}
+	if err := class.ValidateClass(); err != nil {
+		c.report(MissingClass, section.Line, "%v", err)
+	}
+
+	if entry, ok := section.Entry("DriverVer"); !ok {
+		c.report(MissingDriverVer, section.Line, "the [Version] section does not have a DriverVer directive")
+	} else if _, _, err := inf.ParseDriverVer(entry.Values); err != nil {
+		c.report(InvalidDriverVer, entry.Line, "%v", err)
+	}
+
+	// Invalid DriverVer directives have already been reported
+	c.version, _ = c.doc.Version()
+	c.hasVer = true
+}
+
+func (c *checker) checkStrings() {
+	for _, section := range c.doc.Sections {
+		name := strings.ToLower(section.Name)
+		if name == "strings" || strings.HasPrefix(name, "strings.") {
+			continue
+		}
+		for _, entry := range section.Entries {
+			seen := make(map[string]bool)
+			raw := append([]string{entry.RawKey}, entry.RawValues...)
+			for _, value := range raw {
+				for _, token := range c.doc.Unresolved(value) {
+					// Numeric tokens such as %12% are directory IDs
+					if isDirID(token) {
+						continue
+					}
+					key := strings.ToLower(token)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					c.report(UndefinedString, entry.Line, "%%%s%% is not defined in the [Strings] section", token)
+				}
+			}
+		}
+	}
+}
+
+func (c *checker) checkManufacturers() {
+	if !c.doc.HasSection("Manufacturer") {
+		c.report(MissingManufacturers, 0, "the file does not have a [Manufacturer] section")
+		return
+	}
+	manufacturers := c.doc.Manufacturers()
+	if len(manufacturers) == 0 {
+		c.report(MissingManufacturers, c.doc.Section("Manufacturer").Line, "the file does not list any manufacturers")
+		return
+	}
+
+	c.archs = make(map[inf.Architecture]bool)
+	var (
+		ids      = make(map[string]int)  // Decoration and hardware ID to line
+		installs = make(map[string]bool) // Install sections already checked
+	)
+
+	for _, m := range manufacturers {
+		for _, name := range c.doc.ModelsSections(m) {
+			decoration := strings.TrimPrefix(name[len(m.Section):], ".")
+			arch, err := m.Architecture(name)
+			if err != nil {
+				c.report(InvalidDecoration, m.Line, "%v", err)
+				continue
+			}
+
+			if !c.doc.HasSection(name) {
+				c.report(MissingModels, m.Line, "manufacturer \"%s\" refers to models section [%s], which does not exist", m.Name, name)
+				continue
+			}
+			c.archs[arch] = true
+
+			for _, model := range c.doc.ModelsIn(m, name) {
+				c.checkModelIDs(model)
+
+				if model.HardwareID != "" {
+					key := strings.ToLower(decoration) + "|" + strings.ToLower(string(model.HardwareID))
+					if first, dup := ids[key]; dup {
+						c.report(DuplicateHardwareID, model.Line, "hardware ID \"%s\" is already listed on line %d for the same target", model.HardwareID, first)
+					} else {
+						ids[key] = model.Line
+					}
+				}
+
+				if model.InstallSection == "" {
+					c.report(MissingInstall, model.Line, "models entry \"%s\" does not name an install section", model.Description)
+					continue
+				}
+				install := c.doc.InstallSection(model.InstallSection, arch)
+				if install == nil {
+					c.report(MissingInstall, model.Line, "install section [%s] does not exist for %s", model.InstallSection, arch)
+					continue
+				}
+				key := strings.ToLower(install.Name) + "|" + string(arch)
+				if !installs[key] {
+					installs[key] = true
+					c.checkCopyFiles(install, arch)
+				}
+			}
+		}
+	}
+}
+
+func (c *checker) checkModelIDs(model inf.Model) {
+	if err := model.HardwareID.Validate(); err != nil {
+		c.report(InvalidHardwareID, model.Line, "%v", err)
+	}
+	for _, id := range model.CompatibleIDs {
+		if err := id.Validate(); err != nil {
+			c.report(InvalidHardwareID, model.Line, "%v", err)
+		}
+	}
+}
+
+func (c *checker) checkCopyFiles(install *inf.Section, arch inf.Architecture) {
+	for _, directive := range install.Find("CopyFiles") {
+		for _, value := range directive.Values {
+			if value == "" || strings.HasPrefix(value, "@") {
+				continue
+			}
+			if !c.doc.HasSection(value) {
+				c.report(MissingFileList, directive.Line, "file-list section [%s] referenced by [%s] does not exist", value, install.Name)
+			}
+		}
+	}
+
+	// Files in system INF files are described by a separate layout file
+	if c.doc.Value("Version", "LayoutFile") != "" {
+		return
+	}
+
+	for _, file := range c.doc.CopyFiles(install) {
+		if _, ok := c.doc.SourceFile(file.Source, arch); !ok {
+			c.report(MissingSourceFile, file.Line, "\"%s\" is not listed in [SourceDisksFiles] or [SourceDisksFiles.%s]", file.Source, arch)
+		}
+	}
+}
+
+func (c *checker) checkCatalogFiles() {
+	if !c.hasVer {
+		return
+	}
+	line := c.doc.Section("Version").Line
+	for _, arch := range inf.Architectures {
+		if c.archs[arch] && c.version.CatalogFile(arch) == "" {
+			c.report(MissingCatalogFile, line, "no CatalogFile directive applies to %s", arch)
+		}
+	}
+}
+
+func (c *checker) checkRegistry() {
+	for _, section := range c.doc.Sections {
+		for _, invalid := range c.doc.InvalidRegistry(section) {
+			c.report(InvalidRegistry, invalid.Line, "registry entry in [%s] is ignored: %v", invalid.Section, invalid.Err)
+		}
+	}
+}
+
+func (c *checker) checkPackageFiles(path string) {
+	for _, arch := range inf.Architectures {
+		if !c.archs[arch] {
+			continue
+		}
+		manifest := driverpackage.ListFrom(c.doc, path, arch)
+		for _, file := range manifest.MissingFiles() {
+			c.report(MissingPackageFile, 0, "\"%s\" is required on %s but does not exist in the package directory", file.SourcePath, arch)
+		}
+	}
+}
+
+// isDirID returns true if token is a numeric directory ID, such as the 12
+// in %12%.
+func isDirID(token string) bool {
+	if token == "" {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || token[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,7 @@
+// Package inflint reports structural problems in setup information (INF)
+// files.
+//
+// Each problem is reported as a Finding with a stable rule code, so that
+// automated checks can depend on the codes remaining the same between
+// releases.
+package inflint
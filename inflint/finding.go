@@ -0,0 +1,32 @@
+package inflint
+
+import "fmt"
+
+// Finding is a problem found in an INF file.
+type Finding struct {
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// String returns a human readable representation of the finding in the
+// form file:line: code severity: message.
+func (f Finding) String() string {
+	location := f.File
+	if f.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, f.Line)
+	}
+	return fmt.Sprintf("%s: %s %s: %s", location, f.Code, f.Severity, f.Message)
+}
+
+// HasErrors returns true if any of the findings have error severity.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
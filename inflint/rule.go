@@ -0,0 +1,60 @@
+package inflint
+
+// Severity indicates how serious a finding is.
+type Severity string
+
+// Finding severities.
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Rule describes a single check. Rule codes are stable and will not be
+// reused for different checks.
+type Rule struct {
+	Code     string
+	Severity Severity
+	Summary  string
+}
+
+// Rules checked by the linter.
+var (
+	ParseFailed          = Rule{"INF001", Error, "the file could not be parsed"}
+	MissingVersion       = Rule{"INF002", Error, "the [Version] section is missing"}
+	MissingClass         = Rule{"INF003", Error, "the device setup class is missing or invalid"}
+	InvalidDriverVer     = Rule{"INF004", Error, "the DriverVer directive has an invalid date or version"}
+	MissingDriverVer     = Rule{"INF005", Warning, "the DriverVer directive is missing"}
+	MissingCatalogFile   = Rule{"INF006", Warning, "no CatalogFile directive applies to a targeted architecture"}
+	UndefinedString      = Rule{"INF007", Error, "a %strkey% token is not defined in the [Strings] section"}
+	InvalidDecoration    = Rule{"INF008", Error, "a TargetOSVersion decoration is invalid"}
+	MissingModels        = Rule{"INF009", Error, "a models section referenced by [Manufacturer] is missing"}
+	MissingInstall       = Rule{"INF010", Error, "an install section referenced by a models entry is missing"}
+	DuplicateHardwareID  = Rule{"INF011", Warning, "a hardware ID is listed more than once for the same target"}
+	InvalidHardwareID    = Rule{"INF012", Error, "a hardware or compatible ID is invalid"}
+	MissingFileList      = Rule{"INF013", Error, "a file-list section referenced by CopyFiles is missing"}
+	MissingSourceFile    = Rule{"INF014", Error, "a copied file is not listed in [SourceDisksFiles]"}
+	MissingManufacturers = Rule{"INF015", Warning, "the [Manufacturer] section is missing or empty"}
+	MissingPackageFile   = Rule{"INF016", Error, "a file required by the package does not exist in the package directory"}
+	InvalidRegistry      = Rule{"INF017", Error, "a line of an AddReg, DelReg or BitReg section does not start with a registry root key"}
+)
+
+// Rules lists every rule in code order.
+var Rules = []Rule{
+	ParseFailed,
+	MissingVersion,
+	MissingClass,
+	InvalidDriverVer,
+	MissingDriverVer,
+	MissingCatalogFile,
+	UndefinedString,
+	InvalidDecoration,
+	MissingModels,
+	MissingInstall,
+	DuplicateHardwareID,
+	InvalidHardwareID,
+	MissingFileList,
+	MissingSourceFile,
+	MissingManufacturers,
+	MissingPackageFile,
+	InvalidRegistry,
+}
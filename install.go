@@ -7,6 +7,7 @@ import (
 	"github.com/gentlemanautomaton/windevice/deviceid"
 	"github.com/gentlemanautomaton/windevice/deviceregistry"
 	"github.com/gentlemanautomaton/windevice/difunc"
+	"github.com/gentlemanautomaton/windevice/inf"
 	"github.com/gentlemanautomaton/windevice/infpath"
 	"github.com/gentlemanautomaton/windevice/installflag"
 	"github.com/gentlemanautomaton/windevice/newdevapi"
@@ -27,6 +28,18 @@ func Install(id deviceid.Hardware, path, description string, flags installflag.V
 		return "", false, err
 	}
 
+	// Make sure the INF file specifies a device setup class before asking
+	// windows to do anything with it. Problems with the rest of the
+	// [Version] section, such as an invalid DriverVer, are left to windows.
+	// Files that cannot be parsed here are also left to windows, which
+	// remains the authority on what it can install.
+	if doc, err := inf.Load(path); err == nil {
+		version, _ := doc.Version()
+		if err := version.ValidateClass(); err != nil {
+			return "", false, err
+		}
+	}
+
 	// Ask windows to retrieve the name and GUID from the INF file
 	name, guid, err := setupapi.GetInfClass(path)
 	if err != nil {
@@ -0,0 +1,99 @@
+// Package locationpath parses device location paths.
+//
+// A location path describes the position of a device in the device tree
+// in terms of the buses that lead to it, such as
+// PCIROOT(0)#PCI(1C04)#PCI(0000)#USBROOT(0)#USB(2) or ACPI(_SB_)#ACPI(PCI0).
+// Unlike device instance identifiers, location paths remain stable when a
+// device is reinstalled.
+//
+// https://docs.microsoft.com/en-us/windows-hardware/drivers/install/devpkey-device-locationpaths
+package locationpath
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Separator separates the segments of a location path.
+const Separator = "#"
+
+// Path is a device location path.
+type Path string
+
+// Validate returns an error if the location path is not valid.
+func (p Path) Validate() error {
+	_, err := p.Segments()
+	return err
+}
+
+// Segments parses p and returns its segments.
+func (p Path) Segments() ([]Segment, error) {
+	if p == "" {
+		return nil, errors.New("an empty location path was provided")
+	}
+	parts := strings.Split(string(p), Separator)
+	segments := make([]Segment, 0, len(parts))
+	for i, part := range parts {
+		segment, err := ParseSegment(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid location path \"%s\": segment %d: %v", p, i, err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// Parent returns the location path of the parent of p. It returns an empty
+// path if p has only one segment.
+func (p Path) Parent() Path {
+	i := strings.LastIndex(string(p), Separator)
+	if i < 0 {
+		return ""
+	}
+	return p[:i]
+}
+
+// Equal returns true if p and other describe the same location. The
+// comparison is not case-sensitive.
+func (p Path) Equal(other Path) bool {
+	return strings.EqualFold(string(p), string(other))
+}
+
+// IsAncestorOf returns true if p is a proper prefix of other, which means
+// that the device at other sits behind the device at p. The comparison is
+// not case-sensitive.
+func (p Path) IsAncestorOf(other Path) bool {
+	if p == "" || len(other) <= len(p) {
+		return false
+	}
+	prefix := other[:len(p)]
+	return p.Equal(prefix) && strings.HasPrefix(string(other[len(p):]), Separator)
+}
+
+// IsDescendantOf returns true if the device at p sits behind the device at
+// ancestor.
+func (p Path) IsDescendantOf(ancestor Path) bool {
+	return ancestor.IsAncestorOf(p)
+}
+
+// CommonAncestor returns the longest location path that is equal to or
+// an ancestor of both a and b. It returns an empty path if a and b do not
+// share a root segment.
+func CommonAncestor(a, b Path) Path {
+	as := strings.Split(string(a), Separator)
+	bs := strings.Split(string(b), Separator)
+	n := 0
+	for n < len(as) && n < len(bs) && strings.EqualFold(as[n], bs[n]) {
+		n++
+	}
+	return Path(strings.Join(as[:n], Separator))
+}
+
+// Depth returns the number of segments in p.
+func (p Path) Depth() int {
+	if p == "" {
+		return 0
+	}
+	return strings.Count(string(p), Separator) + 1
+}
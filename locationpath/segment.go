@@ -0,0 +1,86 @@
+package locationpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Well-known segment types.
+const (
+	ACPI    = "ACPI"
+	PCIRoot = "PCIROOT"
+	PCI     = "PCI"
+	USBRoot = "USBROOT"
+	USB     = "USB"
+)
+
+// Segment is a single segment of a location path, such as PCI(1C04).
+type Segment struct {
+	Type    string // PCI
+	Address string // 1C04
+}
+
+// ParseSegment parses a single location path segment in the form
+// TYPE(ADDRESS).
+func ParseSegment(s string) (Segment, error) {
+	if s == "" {
+		return Segment{}, errors.New("empty segment")
+	}
+	open := strings.IndexByte(s, '(')
+	if open <= 0 || !strings.HasSuffix(s, ")") {
+		return Segment{}, fmt.Errorf("segment \"%s\" does not have the form TYPE(ADDRESS)", s)
+	}
+	segment := Segment{
+		Type:    s[:open],
+		Address: s[open+1 : len(s)-1],
+	}
+	for i := 0; i < len(segment.Type); i++ {
+		c := segment.Type[i]
+		if !(c >= 'A' && c <= 'Z') && !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			return Segment{}, fmt.Errorf("segment \"%s\" has an invalid type", s)
+		}
+	}
+	if strings.ContainsAny(segment.Address, "()#") {
+		return Segment{}, fmt.Errorf("segment \"%s\" has an invalid address", s)
+	}
+	return segment, nil
+}
+
+// Is returns true if the segment has the given type. The comparison is not
+// case-sensitive.
+func (s Segment) Is(t string) bool {
+	return strings.EqualFold(s.Type, t)
+}
+
+// PCI interprets the segment as a PCI segment with an address in the form
+// DDFF, where DD is the device number and FF is the function number, both
+// in hexadecimal. It returns false if the segment is not a valid PCI
+// segment.
+func (s Segment) PCI() (device, function uint8, ok bool) {
+	if !s.Is(PCI) || len(s.Address) != 4 {
+		return 0, 0, false
+	}
+	v, err := strconv.ParseUint(s.Address, 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint8(v >> 8), uint8(v), true
+}
+
+// Number interprets the address of the segment as a decimal number,
+// as used by PCIROOT, USBROOT and USB segments. It returns false if the
+// address is not a number.
+func (s Segment) Number() (uint64, bool) {
+	v, err := strconv.ParseUint(s.Address, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// String returns a string representation of the segment.
+func (s Segment) String() string {
+	return s.Type + "(" + s.Address + ")"
+}
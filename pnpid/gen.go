@@ -0,0 +1,85 @@
+//go:build ignore
+
+// This program generates tables.go from vendors.txt and devices.txt.
+// Invoke it with "go generate".
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	vendors, err := load("vendors.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	devices, err := load("devices.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen.go from vendors.txt and devices.txt; DO NOT EDIT.\n\n")
+	buf.WriteString("package pnpid\n\n")
+	writeMap(&buf, "vendors", "vendors maps PNP and ACPI vendor IDs to vendor names.", vendors)
+	buf.WriteString("\n")
+	writeMap(&buf, "devices", "devices maps PNP and ACPI device IDs to device type descriptions.", devices)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("tables.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load reads a tab-separated data file and returns its entries keyed by
+// upper case ID.
+func load(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		id, name, found := strings.Cut(text, "\t")
+		if !found {
+			return nil, fmt.Errorf("%s:%d: missing tab separator", path, line)
+		}
+		id = strings.ToUpper(strings.TrimSpace(id))
+		if _, exists := entries[id]; exists {
+			return nil, fmt.Errorf("%s:%d: duplicate entry for %s", path, line, id)
+		}
+		entries[id] = strings.TrimSpace(name)
+	}
+	return entries, scanner.Err()
+}
+
+func writeMap(buf *bytes.Buffer, name, comment string, entries map[string]string) {
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	fmt.Fprintf(buf, "// %s\nvar %s = map[string]string{\n", comment, name)
+	for _, id := range ids {
+		fmt.Fprintf(buf, "\t%q: %q,\n", id, entries[id])
+	}
+	buf.WriteString("}\n")
+}
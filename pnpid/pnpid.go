@@ -0,0 +1,24 @@
+// Package pnpid maps PNP and ACPI vendor and device IDs to human readable
+// names.
+//
+// The tables are generated from vendors.txt and devices.txt. They hold
+// a subset of the registries maintained by the UEFI Forum.
+package pnpid
+
+import "strings"
+
+//go:generate go run gen.go
+
+// Vendor returns the name of the vendor with the given three-letter PNP
+// vendor ID or four-character ACPI vendor ID. It returns an empty string if
+// the vendor is not known.
+func Vendor(id string) string {
+	return vendors[strings.ToUpper(id)]
+}
+
+// Device returns a description of the device type with the given PNP or
+// ACPI device ID, such as PNP0A08. It returns an empty string if the device
+// type is not known.
+func Device(id string) string {
+	return devices[strings.ToUpper(id)]
+}
@@ -0,0 +1,134 @@
+// Code generated by gen.go from vendors.txt and devices.txt; DO NOT EDIT.
+
+package pnpid
+
+// vendors maps PNP and ACPI vendor IDs to vendor names.
+var vendors = map[string]string{
+	"ACI":  "Ancor Communications Inc",
+	"ACPI": "ACPI Specification",
+	"ACR":  "Acer Technologies",
+	"ALP":  "Alps Electric Company Ltd",
+	"AMDI": "Advanced Micro Devices Inc",
+	"AOC":  "AOC International (USA) Ltd",
+	"APP":  "Apple Computer Inc",
+	"ASUS": "ASUSTeK Computer Inc",
+	"ATML": "Atmel Corporation",
+	"AUO":  "AU Optronics",
+	"AUS":  "ASUSTeK Computer Inc",
+	"BNQ":  "BenQ Corporation",
+	"BOE":  "BOE Technology Group",
+	"BRCM": "Broadcom Corporation",
+	"CMN":  "Chimei Innolux Corporation",
+	"CMO":  "Chi Mei Optoelectronics Corp",
+	"CPQ":  "Compaq Computer Company",
+	"CSO":  "China Star Optoelectronics",
+	"DEL":  "Dell Inc",
+	"DELL": "Dell Inc",
+	"DWE":  "Daewoo Electronics Company Ltd",
+	"ECS":  "Elitegroup Computer Systems Company Ltd",
+	"ELAN": "ELAN Microelectronics Corporation",
+	"ELO":  "Elo TouchSystems Inc",
+	"ENC":  "Eizo Nanao Corporation",
+	"EPI":  "Envision Peripherals Inc",
+	"FUJ":  "Fujitsu Ltd",
+	"FUS":  "Fujitsu Siemens Computers GmbH",
+	"GBT":  "Giga-Byte Technology Co Ltd",
+	"GOOG": "Google Inc",
+	"GSM":  "LG Electronics",
+	"GWY":  "Gateway 2000",
+	"HEI":  "Hyundai Electronics Industries Co Ltd",
+	"HPN":  "HP Inc",
+	"HPQC": "Hewlett-Packard Company",
+	"HSD":  "HannStar Display Corp",
+	"HWP":  "Hewlett Packard",
+	"IBM":  "IBM Corporation",
+	"INT":  "Intel Corporation",
+	"INTC": "Intel Corporation",
+	"IVM":  "Iiyama North America",
+	"IVO":  "InfoVision Optoelectronics",
+	"LEN":  "Lenovo Group Limited",
+	"LGD":  "LG Display",
+	"LOG":  "Logitech Inc",
+	"LPL":  "LG Philips",
+	"MEI":  "Panasonic Industry Company",
+	"MEL":  "Mitsubishi Electric Corporation",
+	"MSFT": "Microsoft Corporation",
+	"MSI":  "Micro-Star International Co Ltd",
+	"NEC":  "NEC Corporation",
+	"NVD":  "NVIDIA Corporation",
+	"NVDA": "NVIDIA Corporation",
+	"PHL":  "Philips Consumer Electronics Company",
+	"PNP":  "Microsoft Corporation",
+	"QCOM": "Qualcomm Inc",
+	"QDS":  "Quanta Display Inc",
+	"QEMU": "QEMU",
+	"SAM":  "Samsung Electric Company",
+	"SDC":  "Samsung Display Corp",
+	"SEC":  "Seiko Epson Corporation",
+	"SHP":  "Sharp Corporation",
+	"SIS":  "Silicon Integrated Systems Corporation",
+	"SNY":  "Sony",
+	"SYN":  "Synaptics Inc",
+	"SYNA": "Synaptics Inc",
+	"TOS":  "Toshiba Corporation",
+	"TSB":  "Toshiba America Info Systems Inc",
+	"VIA":  "VIA Technologies Inc",
+	"VMW":  "VMware Inc",
+	"VSC":  "ViewSonic Corporation",
+	"WAC":  "Wacom Tech",
+}
+
+// devices maps PNP and ACPI device IDs to device type descriptions.
+var devices = map[string]string{
+	"ACPI0003": "Power Source Device",
+	"ACPI0004": "Module Device",
+	"ACPI0007": "Processor Device",
+	"ACPI0008": "Ambient Light Sensor",
+	"ACPI000C": "Processor Aggregator Device",
+	"ACPI000D": "Power Meter",
+	"ACPI000E": "Time and Alarm Device",
+	"ACPI0010": "Processor Container Device",
+	"MSFT0101": "Trusted Platform Module 2.0",
+	"PNP0000":  "AT Interrupt Controller",
+	"PNP0001":  "EISA Interrupt Controller",
+	"PNP0003":  "Advanced Programmable Interrupt Controller",
+	"PNP0100":  "AT Timer",
+	"PNP0103":  "High Precision Event Timer",
+	"PNP0200":  "AT DMA Controller",
+	"PNP0300":  "IBM PC/XT Keyboard Controller (83-key)",
+	"PNP0303":  "IBM Enhanced Keyboard (101/102-key, PS/2 Mouse Support)",
+	"PNP0400":  "Standard LPT Printer Port",
+	"PNP0401":  "ECP Printer Port",
+	"PNP0500":  "Standard PC COM Port",
+	"PNP0501":  "16550A-compatible COM Port",
+	"PNP0700":  "PC Standard Floppy Disk Controller",
+	"PNP0800":  "AT-style Speaker Sound",
+	"PNP09FF":  "Plug and Play Monitor",
+	"PNP0A03":  "PCI Bus",
+	"PNP0A05":  "Generic Container Device",
+	"PNP0A06":  "Generic Container Device (Extended I/O Bus)",
+	"PNP0A08":  "PCI Express Root Complex",
+	"PNP0B00":  "AT Real-Time Clock",
+	"PNP0C01":  "System Board",
+	"PNP0C02":  "Motherboard Resources",
+	"PNP0C04":  "Math Coprocessor",
+	"PNP0C08":  "ACPI System",
+	"PNP0C09":  "Embedded Controller",
+	"PNP0C0A":  "Control Method Battery",
+	"PNP0C0B":  "Fan",
+	"PNP0C0C":  "Power Button",
+	"PNP0C0D":  "Lid",
+	"PNP0C0E":  "Sleep Button",
+	"PNP0C0F":  "PCI Interrupt Link",
+	"PNP0C10":  "System Indicator",
+	"PNP0C11":  "Thermal Zone",
+	"PNP0C12":  "Device Bay Controller",
+	"PNP0C14":  "Windows Management Instrumentation",
+	"PNP0C15":  "Docking Station",
+	"PNP0C40":  "Windows-compatible Button Array",
+	"PNP0C50":  "HID over I2C Device",
+	"PNP0C80":  "Memory Device",
+	"PNP0D10":  "XHCI-compliant USB Controller",
+	"PNP0F03":  "Microsoft PS/2-style Mouse",
+	"PNP0F13":  "PS/2 Port for PS/2-style Mice",
+}
@@ -10,6 +10,7 @@ import (
 var (
 	procSetupDiGetDevicePropertyKeys = modsetupapi.NewProc("SetupDiGetDevicePropertyKeys")
 	procSetupDiGetDeviceProperty     = modsetupapi.NewProc("SetupDiGetDevicePropertyW")
+	procSetupDiSetDeviceProperty     = modsetupapi.NewProc("SetupDiSetDevicePropertyW")
 )
 
 // GetDevicePropertyKeys returns all of the property keys for a device
@@ -115,3 +116,40 @@ func getDeviceProperty(devices syscall.Handle, device DevInfoData, key devicepro
 	}
 	return
 }
+
+// SetDeviceProperty sets a device property for a device instance.
+// It calls the SetupDiSetDevicePropertyW windows API function.
+//
+// Windows deletes the property when the value has the DEVPROP_TYPE_EMPTY
+// type and no data, which is the case for the zero Value.
+//
+// https://docs.microsoft.com/en-us/windows/desktop/api/setupapi/nf-setupapi-setupdisetdevicepropertyw
+func SetDeviceProperty(devices syscall.Handle, device DevInfoData, key deviceproperty.Key, value deviceproperty.Value) (err error) {
+	dataType := value.Type()
+	buffer := value.Bytes()
+
+	var pb *byte
+	if len(buffer) > 0 {
+		pb = &buffer[0]
+	}
+
+	r0, _, e := syscall.Syscall9(
+		procSetupDiSetDeviceProperty.Addr(),
+		7,
+		uintptr(devices),
+		uintptr(unsafe.Pointer(&device)),
+		uintptr(unsafe.Pointer(&key)),
+		uintptr(dataType),
+		uintptr(unsafe.Pointer(pb)),
+		uintptr(len(buffer)),
+		0, // Flags are always zero
+		0,
+		0)
+	if r0 == 0 {
+		if e != 0 {
+			return syscall.Errno(e)
+		}
+		return syscall.EINVAL
+	}
+	return nil
+}